@@ -0,0 +1,234 @@
+// Package chans provides generic helpers to combine and split chans.
+//
+// Every helper takes a context: its go routines return and its output chans are closed
+// when the input chans are closed or when the context is done, whichever comes first,
+// so that no go routine is leaked as long as one of them happens.
+package chans
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// send writes v to out, it returns false if ctx is done first.
+func send[T any](ctx context.Context, out chan<- T, v T) bool {
+	select {
+	case out <- v:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// recv reads a value from in, ok is false if in is closed or if ctx is done first.
+func recv[T any](ctx context.Context, in <-chan T) (v T, ok bool) {
+	select {
+	case v, ok = <-in:
+		return v, ok
+	case <-ctx.Done():
+		return v, false
+	}
+}
+
+// OrDone returns a chan yielding the values of in until in is closed or ctx is done.
+// It lets a consumer range over a chan without checking ctx on each value.
+func OrDone[T any](ctx context.Context, in <-chan T) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for {
+			v, ok := recv(ctx, in)
+			if !ok || !send(ctx, out, v) {
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Merge returns a chan yielding the values of all the given chans, in the order they are received.
+// It is closed once all of them are closed or ctx is done.
+func Merge[T any](ctx context.Context, ins ...<-chan T) <-chan T {
+	out := make(chan T)
+	var wg sync.WaitGroup
+	wg.Add(len(ins))
+	for _, in := range ins {
+		go func(in <-chan T) {
+			defer wg.Done()
+			for {
+				v, ok := recv(ctx, in)
+				if !ok || !send(ctx, out, v) {
+					return
+				}
+			}
+		}(in)
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// FanOut distributes the values of in over n chans, each value being written to only one of them.
+// Each chan is fed by its own go routine reading in, a value goes to the go routine which read it first,
+// which holds it until the reader of its chan is ready: a slow reader delays the value it got, not the others.
+// All of them are closed once in is closed or ctx is done.
+// FanOut panics if n is lower than 1.
+func FanOut[T any](ctx context.Context, in <-chan T, n int) []<-chan T {
+	if n < 1 {
+		panic("chans: FanOut given n < 1")
+	}
+	outs := make([]<-chan T, n)
+	for i := range outs {
+		out := make(chan T)
+		outs[i] = out
+		go func() {
+			defer close(out)
+			for {
+				v, ok := recv(ctx, in)
+				if !ok || !send(ctx, out, v) {
+					return
+				}
+			}
+		}()
+	}
+	return outs
+}
+
+// Broadcast writes every value of in to each of n chans.
+// A value is read from in once the previous one has been written to all the chans,
+// the chans go at the pace of the slowest reader.
+// All of them are closed once in is closed or ctx is done.
+// Broadcast panics if n is lower than 1.
+func Broadcast[T any](ctx context.Context, in <-chan T, n int) []<-chan T {
+	if n < 1 {
+		panic("chans: Broadcast given n < 1")
+	}
+	outs := make([]chan T, n)
+	ret := make([]<-chan T, n)
+	for i := range outs {
+		outs[i] = make(chan T)
+		ret[i] = outs[i]
+	}
+	go func() {
+		defer func() {
+			for _, out := range outs {
+				close(out)
+			}
+		}()
+		for {
+			v, ok := recv(ctx, in)
+			if !ok {
+				return
+			}
+			for _, out := range outs {
+				if !send(ctx, out, v) {
+					return
+				}
+			}
+		}
+	}()
+	return ret
+}
+
+// Tee writes every value of in to both returned chans, it is a Broadcast to 2 chans.
+func Tee[T any](ctx context.Context, in <-chan T) (<-chan T, <-chan T) {
+	outs := Broadcast(ctx, in, 2)
+	return outs[0], outs[1]
+}
+
+// Bridge returns a chan yielding the values of each chan read from chs, one chan after the other.
+// It is closed once chs and the last chan read from it are closed, or ctx is done.
+func Bridge[T any](ctx context.Context, chs <-chan (<-chan T)) <-chan T {
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		for {
+			in, ok := recv(ctx, chs)
+			if !ok {
+				return
+			}
+			if in == nil {
+				continue
+			}
+			for {
+				v, ok := recv(ctx, in)
+				if !ok {
+					break
+				}
+				if !send(ctx, out, v) {
+					return
+				}
+			}
+			if ctx.Err() != nil {
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Batch groups the values of in in slices of size values.
+// A batch is written before it is full when maxWait has elapsed since its first value was read,
+// a maxWait of 0 means batches are only written when full.
+// The last batch is written when in is closed, the values of the pending batch are dropped if ctx is done.
+// Batch panics if size is lower than 1.
+func Batch[T any](ctx context.Context, in <-chan T, size int, maxWait time.Duration) <-chan []T {
+	if size < 1 {
+		panic("chans: Batch given size < 1")
+	}
+	out := make(chan []T)
+	go func() {
+		defer close(out)
+		var batch []T
+		var timer *time.Timer
+		var expired <-chan time.Time
+		flush := func() bool {
+			if timer != nil {
+				timer.Stop()
+				timer, expired = nil, nil
+			}
+			if len(batch) == 0 {
+				return true
+			}
+			b := batch
+			batch = nil
+			return send(ctx, out, b)
+		}
+		defer func() {
+			if timer != nil {
+				timer.Stop()
+			}
+		}()
+		for {
+			select {
+			case v, ok := <-in:
+				if !ok {
+					flush()
+					return
+				}
+				if batch == nil {
+					batch = make([]T, 0, size)
+					if maxWait > 0 {
+						timer = time.NewTimer(maxWait)
+						expired = timer.C
+					}
+				}
+				batch = append(batch, v)
+				if len(batch) == size && !flush() {
+					return
+				}
+			case <-expired:
+				timer, expired = nil, nil
+				if !flush() {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
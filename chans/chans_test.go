@@ -0,0 +1,200 @@
+package chans
+
+import (
+	"context"
+	"runtime"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func gen(values ...int) <-chan int {
+	c := make(chan int)
+	go func() {
+		defer close(c)
+		for _, v := range values {
+			c <- v
+		}
+	}()
+	return c
+}
+
+func collect[T any](c <-chan T) []T {
+	var ret []T
+	for v := range c {
+		ret = append(ret, v)
+	}
+	return ret
+}
+
+// noLeak fails the test if the number of go routines does not go back to n.
+func noLeak(t *testing.T, n int) {
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > n {
+		if time.Now().After(deadline) {
+			t.Fatalf("go routines leaked: %d running, %d before", runtime.NumGoroutine(), n)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestOrDone(t *testing.T) {
+	assert.Equal(t, []int{1, 2, 3}, collect(OrDone(context.Background(), gen(1, 2, 3))), "OrDone should yield all values")
+
+	n := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan int)
+	out := OrDone(ctx, in)
+	cancel()
+	_, ok := <-out
+	assert.False(t, ok, "out should be closed once ctx is done")
+	noLeak(t, n)
+}
+
+func TestMerge(t *testing.T) {
+	merged := collect(Merge(context.Background(), gen(1, 2), gen(3), gen(4, 5, 6)))
+	sort.Ints(merged)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, merged, "Merge should yield the values of all chans")
+	assert.Empty(t, collect(Merge[int](context.Background())), "Merge of no chans should be closed")
+
+	n := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+	out := Merge(ctx, make(chan int), make(chan int))
+	cancel()
+	assert.Empty(t, collect(out), "out should be closed once ctx is done")
+	noLeak(t, n)
+}
+
+func TestFanOut(t *testing.T) {
+	outs := FanOut(context.Background(), gen(1, 2, 3, 4, 5, 6, 7, 8), 3)
+	assert.Len(t, outs, 3, "FanOut should return 3 chans")
+
+	var mu sync.Mutex
+	var all []int
+	var wg sync.WaitGroup
+	for _, out := range outs {
+		wg.Add(1)
+		go func(out <-chan int) {
+			defer wg.Done()
+			values := collect(out)
+			mu.Lock()
+			all = append(all, values...)
+			mu.Unlock()
+		}(out)
+	}
+	wg.Wait()
+	sort.Ints(all)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8}, all, "every value should be written to exactly one chan")
+
+	n := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+	outs = FanOut(ctx, make(chan int), 2)
+	cancel()
+	for _, out := range outs {
+		collect(out)
+	}
+	noLeak(t, n)
+
+	assert.Panics(t, func() { FanOut(context.Background(), gen(), 0) }, "n < 1 should panic")
+}
+
+func TestBroadcast(t *testing.T) {
+	outs := Broadcast(context.Background(), gen(1, 2, 3), 3)
+	results := make([][]int, len(outs))
+	var wg sync.WaitGroup
+	for i, out := range outs {
+		wg.Add(1)
+		go func(i int, out <-chan int) {
+			defer wg.Done()
+			results[i] = collect(out)
+		}(i, out)
+	}
+	wg.Wait()
+	for _, result := range results {
+		assert.Equal(t, []int{1, 2, 3}, result, "every chan should get every value")
+	}
+
+	n := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan int, 3)
+	in <- 1
+	in <- 2
+	in <- 3
+	outs = Broadcast(ctx, in, 2)
+	// only the first chan is read, the broadcast blocks on the second one until ctx is done
+	v := <-outs[0]
+	assert.Equal(t, 1, v, "first value should be 1")
+	cancel()
+	collect(outs[0])
+	collect(outs[1])
+	noLeak(t, n)
+
+	assert.Panics(t, func() { Broadcast(context.Background(), gen(), 0) }, "n < 1 should panic")
+}
+
+func TestTee(t *testing.T) {
+	a, b := Tee(context.Background(), gen(1, 2, 3))
+	var got []int
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		got = collect(b)
+	}()
+	assert.Equal(t, []int{1, 2, 3}, collect(a), "first chan should get every value")
+	<-done
+	assert.Equal(t, []int{1, 2, 3}, got, "second chan should get every value")
+}
+
+func TestBridge(t *testing.T) {
+	chs := make(chan (<-chan int))
+	go func() {
+		defer close(chs)
+		chs <- gen(1, 2)
+		chs <- nil
+		chs <- gen(3)
+		chs <- gen(4, 5)
+	}()
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, collect(Bridge(context.Background(), chs)), "Bridge should yield the values of each chan in order")
+
+	n := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+	chs = make(chan (<-chan int), 1)
+	chs <- make(chan int)
+	out := Bridge(ctx, chs)
+	cancel()
+	assert.Empty(t, collect(out), "out should be closed once ctx is done")
+	noLeak(t, n)
+}
+
+func TestBatch(t *testing.T) {
+	batches := collect(Batch(context.Background(), gen(1, 2, 3, 4, 5, 6, 7), 3, 0))
+	assert.Equal(t, [][]int{{1, 2, 3}, {4, 5, 6}, {7}}, batches, "Batch should group values by 3 and write the last one on close")
+
+	in := make(chan int)
+	out := Batch(context.Background(), in, 10, 20*time.Millisecond)
+	in <- 1
+	in <- 2
+	select {
+	case b := <-out:
+		assert.Equal(t, []int{1, 2}, b, "the partial batch should be written after maxWait")
+	case <-time.After(time.Second):
+		t.Fatal("the partial batch should be written after maxWait")
+	}
+	in <- 3
+	close(in)
+	assert.Equal(t, [][]int{{3}}, collect(out), "the last batch should be written on close")
+
+	n := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+	in = make(chan int)
+	out = Batch(ctx, in, 10, time.Hour)
+	in <- 1
+	cancel()
+	assert.Empty(t, collect(out), "the pending batch should be dropped once ctx is done")
+	noLeak(t, n)
+
+	assert.Panics(t, func() { Batch(context.Background(), gen(), 0, 0) }, "size < 1 should panic")
+}
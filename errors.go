@@ -0,0 +1,64 @@
+package lists
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrorMode sets how the errors returned by the go routines of async methods are collected.
+type ErrorMode int
+
+const (
+	// FirstError stops starting go routines on the first error and returns it.
+	FirstError ErrorMode = iota
+	// JoinErrors runs every go routine and returns all the errors joined with errors.Join, which requires go 1.20.
+	// errors.Is and errors.As find any of the joined errors.
+	JoinErrors
+)
+
+// ElementError is the error returned by async methods when the go routine of an element failed.
+// Key is the index (or the map key) of the element.
+type ElementError struct {
+	Key interface{}
+	Err error
+}
+
+func (e *ElementError) Error() string {
+	return fmt.Sprintf("lists: element %v: %s", e.Key, e.Err)
+}
+
+// Unwrap returns the error returned by the go routine.
+func (e *ElementError) Unwrap() error {
+	return e.Err
+}
+
+// PanicError is the error a recovered panic is converted to, see PanicMode.
+// Stack is the stack trace of the go routine which panicked, it is left out of the message
+// so that joined errors of several panics stay readable.
+type PanicError struct {
+	Value interface{}
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// ProtocolError is the error returned by async methods when a go routine misuses the chan it is given,
+// wrapped in an *ElementError naming the element (or the index written to the chan). See Options.Debug.
+type ProtocolError struct {
+	Reason string
+}
+
+func (e *ProtocolError) Error() string {
+	return "chan protocol: " + e.Reason
+}
+
+// ErrElementTimeout is the error of an element which did not complete within Options.ElementTimeout,
+// wrapped in an *ElementError naming the element.
+var ErrElementTimeout = errors.New("timed out")
+
+// ErrElementAborted is the error given to Observer.OnElementDone for an element still running when the async method
+// returned early, on an error or once its context was done. It is reported once the go routine of the element returns.
+// The element did not fail, its result was dropped.
+var ErrElementAborted = errors.New("aborted")
@@ -0,0 +1,27 @@
+package lists
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestElementError(t *testing.T) {
+	errBoom := errors.New("boom")
+	err := error(&ElementError{Key: "foo", Err: errBoom})
+
+	assert.Equal(t, "lists: element foo: boom", err.Error(), "message should include the key")
+	assert.True(t, errors.Is(err, errBoom), "element error should unwrap to the original error")
+}
+
+func TestPanicError(t *testing.T) {
+	err := error(&PanicError{Value: "boom", Stack: []byte("stack")})
+	assert.Equal(t, "panic: boom", err.Error(), "message should include the value but not the stack")
+	assert.Equal(t, []byte("stack"), err.(*PanicError).Stack, "stack should be kept in the Stack field")
+}
+
+func TestProtocolError(t *testing.T) {
+	err := error(&ElementError{Key: 3, Err: &ProtocolError{Reason: "payload written to the chan more than once"}})
+	assert.Equal(t, "lists: element 3: chan protocol: payload written to the chan more than once", err.Error(), "message should include the key and the reason")
+}
@@ -1,50 +1,62 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
 	"time"
 
+	"github.com/francoispqt/lists"
 	"github.com/francoispqt/lists/slices"
 )
 
 //It calls a test api and retrieves all the 500 comments in the API, it keeps a max concurrency at 100 to avoid maxing file handlers limit
+//Requests are limited to 50 per second, with bursts of 10, to stay within the quota of the API
+//All requests are aborted if they did not complete after 30 seconds, failing requests are logged instead of crashing the program
 func main() {
 
 	start := time.Now()
 
-	result := slices.StringSlice(make([]string, 500)).MapAsync(func(k int, v string, done chan [2]interface{}) {
-
-		// do some async
-		go func() {
-			// build uri
-			uri := fmt.Sprintf("https://jsonplaceholder.typicode.com/comments/%d", k+1)
-			log.Printf("calling :", "GET/"+uri)
-
-			// make get request
-			rs, err := http.Get(uri)
-
-			if err != nil {
-				panic(err) // More idiomatic way would be to print the error and die unless it's a serious error
-			}
-			defer rs.Body.Close()
-
-			bodyBytes, err := ioutil.ReadAll(rs.Body)
-			if err != nil {
-				panic(err)
-			}
-
-			bodyString := string(bodyBytes)
-			log.Printf("got response :", bodyString)
-			// write response to channel
-			// index must be first element
-			done <- [2]interface{}{k, bodyString}
-		}()
-
-	}, 100).Cast()
-
-	log.Printf(fmt.Sprintf("Result length : %d", len(result)))
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	result, err := slices.StringSlice(make([]string, 500)).MapAsyncErr(ctx, func(ctx context.Context, k int, v string) (string, error) {
+		// build uri
+		uri := fmt.Sprintf("https://jsonplaceholder.typicode.com/comments/%d", k+1)
+		log.Printf("calling : %s", "GET/"+uri)
+
+		// make get request, it is aborted when the context is done
+		rq, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+		if err != nil {
+			return "", err
+		}
+		rs, err := http.DefaultClient.Do(rq)
+		if err != nil {
+			return "", err
+		}
+		defer rs.Body.Close()
+
+		bodyBytes, err := ioutil.ReadAll(rs.Body)
+		if err != nil {
+			return "", err
+		}
+
+		bodyString := string(bodyBytes)
+		log.Printf("got response : %s", bodyString)
+		return bodyString, nil
+	}, lists.Options{
+		MaxConcurrency: 100,
+		RateLimit:      lists.NewLimiter(50, 10),
+		ErrorMode:      lists.JoinErrors,
+	})
+
+	// with lists.JoinErrors every request is made, err holds the errors of all failed requests
+	if err != nil {
+		log.Printf("Some requests failed : %s", err)
+	}
+
+	log.Printf("Result length : %d", len(result))
 	log.Printf("Map async took %s", time.Since(start))
 }
@@ -0,0 +1,791 @@
+// Package async holds the engine shared by the async methods of slices and maps.
+package async
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/francoispqt/lists"
+)
+
+// Conc returns the max concurrency passed as optional argument to an async method.
+func Conc(maxConcurrency []int) int {
+	if len(maxConcurrency) == 1 {
+		return maxConcurrency[0]
+	}
+	return lists.DEFAULT_CONC
+}
+
+// Index is the key func of slices, the key of an element is its index.
+func Index(i int) interface{} {
+	return i
+}
+
+// Job describes the elements given to Map and MapContext.
+type Job[P any] struct {
+	// N is the number of elements.
+	N int
+	// Key gives the key of element i for the errors returned.
+	Key func(int) interface{}
+	// Launch runs the go routine of element i, which must write a payload to the chan.
+	Launch func(context.Context, int, chan P)
+	// Index returns the element a payload is for, or an error if the payload is malformed.
+	Index func(P) (int, error)
+	// Store stores the payload of element i.
+	Store func(int, P)
+	// Err returns the error held by a payload, for the observer, it may be nil.
+	Err func(P) error
+	// Fallback returns the payload of element i holding a fallback value, or false if the value cannot be held, it may be nil.
+	Fallback func(int, interface{}) (P, bool)
+}
+
+// Map calls job.Launch as a go routine for every element and gives every payload written to the chan to job.Store.
+// If maxConc is higher than 0, no more than maxConc payloads are awaited at the same time,
+// a new go routine is started each time a payload is read from the chan.
+// Map returns once a payload has been read for every element, it panics with the error of a misused chan.
+func Map[P any](maxConc int, job Job[P]) {
+	if err := MapContext(context.Background(), lists.Options{MaxConcurrency: maxConc}, job); err != nil {
+		panic(err)
+	}
+}
+
+// MapContext is Map with a context given to every go routine.
+// Go routines are run in opts.Pool, or in a Pool of maxConc workers started for the call,
+// each one waits for a token of opts.RateLimit before being started.
+// When the context is done, no more go routine is started and MapContext returns ctx.Err() without waiting for the running ones.
+// The chan is buffered so that go routines still running can write to it without blocking.
+// Panics are handled as set by opts.OnPanic.
+// With opts.ElementTimeout, an element whose payload is not read in time is stored from opts.TimeoutFallback with job.Fallback,
+// or reported as lists.ErrElementTimeout, its context is cancelled and the payload it may write later is ignored.
+// Go routines are then started on their own so that the ones which timed out do not hold a worker:
+// without opts.Pool they are not run by a Pool, with it the task of the Pool returns once the element timed out.
+// A malformed payload or an index out of range stops the map with a *lists.ProtocolError,
+// in debug mode so do a payload written twice for the same element
+// and, if lists.DebugGrace is set, go routines which returned without writing to the chan once it elapsed with no progress.
+func MapContext[P any](ctx context.Context, opts lists.Options, job Job[P]) error {
+	err := mapContext(ctx, opts, job)
+	if opts.Observer != nil {
+		opts.Observer.OnComplete(err)
+	}
+	return err
+}
+
+func mapContext[P any](ctx context.Context, opts lists.Options, job Job[P]) (err error) {
+	var n = job.N
+	var maxConc = opts.MaxConcurrency
+	if maxConc <= 0 || maxConc > n {
+		maxConc = n
+	}
+	if err := ctx.Err(); err != nil || n == 0 {
+		return err
+	}
+	var timeout = opts.ElementTimeout
+	pool := opts.Pool
+	if pool == nil && timeout <= 0 {
+		pool = lists.NewPool(maxConc, 0)
+		defer pool.Close()
+	}
+	mapChan := make(chan P, n)
+	panics := make(chan indexedError, n)
+
+	// seen tells the elements done, in debug mode with a grace go routines report when they return
+	// to find the ones which did not write to the chan
+	var debug = opts.Debug || lists.Debug
+	var debugGrace = lists.DebugGrace
+	var seen []bool
+	var returns chan returned
+	var observer = opts.Observer
+	var starts []time.Time
+	if debug || timeout > 0 || observer != nil {
+		seen = make([]bool, n)
+	}
+	// aborts reports the elements still running when the map stops early once their go routine returns
+	var aborts *aborted
+	if observer != nil {
+		starts = make([]time.Time, n)
+		aborts = newAborted(n, func(i int) {
+			observer.OnElementDone(job.Key(i), time.Since(starts[i]), lists.ErrElementAborted)
+		})
+	}
+	if debug && debugGrace > 0 {
+		returns = make(chan returned, n)
+	}
+	var timers []*time.Timer
+	var ctxs []context.Context
+	var cancels []context.CancelFunc
+	var timedOut []bool
+	var timeouts chan int
+	if timeout > 0 {
+		timers = make([]*time.Timer, n)
+		ctxs = make([]context.Context, n)
+		cancels = make([]context.CancelFunc, n)
+		timedOut = make([]bool, n)
+		timeouts = make(chan int, n)
+		defer func() {
+			for i, cancel := range cancels {
+				if cancel != nil {
+					timers[i].Stop()
+					cancel()
+				}
+			}
+		}()
+	}
+	start := func(i int) error {
+		if opts.RateLimit != nil {
+			if err := opts.RateLimit.Wait(ctx); err != nil {
+				return err
+			}
+		}
+		var elemCtx = ctx
+		if timeout > 0 {
+			elemCtx, cancels[i] = context.WithTimeout(ctx, timeout)
+			ctxs[i] = elemCtx
+			timers[i] = time.AfterFunc(timeout, func() {
+				timeouts <- i
+			})
+		}
+		task := func() {
+			var panicked = true
+			if aborts != nil {
+				defer aborts.returned(i)
+			}
+			if returns != nil {
+				defer func() {
+					returns <- returned{index: i, panicked: panicked}
+				}()
+			}
+			defer recoverPanic(opts, job.Key, i, func(err error) {
+				panics <- indexedError{index: i, err: err}
+			})
+			job.Launch(elemCtx, i, mapChan)
+			panicked = false
+		}
+		if pool != nil && timeout > 0 {
+			// the worker is released once the element timed out, its go routine is left running on its own
+			run := task
+			task = func() {
+				finished := make(chan struct{})
+				go func() {
+					defer close(finished)
+					run()
+				}()
+				select {
+				case <-finished:
+				case <-elemCtx.Done():
+				}
+			}
+		}
+		if observer != nil {
+			starts[i] = time.Now()
+			observer.OnStart(job.Key(i))
+		}
+		if pool == nil {
+			go task()
+			return nil
+		}
+		err := pool.Submit(ctx, task)
+		if err != nil && observer != nil {
+			observer.OnElementDone(job.Key(i), time.Since(starts[i]), err)
+		}
+		return err
+	}
+	// done notifies the observer that element i is done
+	done := func(i int, err error) {
+		if observer != nil {
+			observer.OnElementDone(job.Key(i), time.Since(starts[i]), err)
+		}
+	}
+	// expire marks element i as timed out, storing the fallback or reporting the timeout
+	var errs []error
+	expire := func(i int) {
+		seen[i] = true
+		timedOut[i] = true
+		cancels[i]()
+		if p, ok := fallback(opts, job, i); ok {
+			job.Store(i, p)
+			done(i, nil)
+			return
+		}
+		err := &lists.ElementError{Key: job.Key(i), Err: lists.ErrElementTimeout}
+		errs = append(errs, err)
+		done(i, err)
+	}
+
+	sent := 0
+	// elements started but not done when the map stops early are reported done with its error,
+	// so that the observer is told the end of every element it was told the start of
+	if observer != nil {
+		defer func() {
+			for i := 0; i < sent; i++ {
+				if !seen[i] {
+					aborts.abort(i)
+				}
+			}
+		}()
+	}
+	for ; sent < maxConc; sent++ {
+		if err := start(sent); err != nil {
+			return join(append(errs, err)...)
+		}
+	}
+	var running = sent
+	var silent []int
+	var grace <-chan time.Time
+	for received := 0; received < n; {
+		if returns != nil && running == 0 && grace == nil {
+			grace = time.After(debugGrace)
+		}
+		select {
+		case p := <-mapChan:
+			i, err := job.Index(p)
+			if err == nil && (i < 0 || i >= n) {
+				err = ProtocolError(i, "index out of range [0, %d)", n)
+			}
+			if err == nil && timedOut != nil {
+				if timedOut[i] {
+					continue
+				}
+				// a payload written once the deadline of the element passed timed out as well
+				if !seen[i] && ctxs[i].Err() == context.DeadlineExceeded && ctx.Err() == nil {
+					expire(i)
+					break
+				}
+				timers[i].Stop()
+				cancels[i]()
+			}
+			var again bool
+			if err == nil && seen != nil {
+				again = seen[i]
+				if again && debug {
+					err = ProtocolError(job.Key(i), "payload written to the chan more than once")
+				}
+				seen[i] = true
+			}
+			if err != nil {
+				return join(append(errs, err)...)
+			}
+			job.Store(i, p)
+			if !again {
+				done(i, jobErr(job, p))
+			}
+			grace = nil
+		case r := <-panics:
+			if timedOut != nil {
+				if timedOut[r.index] {
+					continue
+				}
+				timers[r.index].Stop()
+				cancels[r.index]()
+			}
+			if seen != nil {
+				seen[r.index] = true
+			}
+			done(r.index, r.err)
+			if opts.OnPanic == lists.PanicAbort {
+				return r.err
+			}
+			errs = append(errs, r.err)
+		case i := <-timeouts:
+			if seen[i] {
+				continue
+			}
+			expire(i)
+		case r := <-returns:
+			running--
+			if !r.panicked && !seen[r.index] {
+				silent = append(silent, r.index)
+			}
+			continue
+		case <-grace:
+			for _, i := range silent {
+				if !seen[i] {
+					errs = append(errs, ProtocolError(job.Key(i), "go routine returned without writing to the chan"))
+				}
+			}
+			return join(errs...)
+		case <-ctx.Done():
+			return join(append(errs, ctx.Err())...)
+		}
+		received++
+		if sent < n && ctx.Err() == nil {
+			if err := start(sent); err != nil {
+				return join(append(errs, err)...)
+			}
+			sent++
+			running++
+		}
+	}
+	return join(errs...)
+}
+
+// aborted tracks the elements abandoned by a call which stopped early, to report each one once both
+// the call abandoned it and its go routine returned, whichever comes last.
+type aborted struct {
+	mu        sync.Mutex
+	abandoned []bool
+	done      []bool
+	report    func(int)
+}
+
+func newAborted(n int, report func(int)) *aborted {
+	return &aborted{abandoned: make([]bool, n), done: make([]bool, n), report: report}
+}
+
+// abort marks element i as abandoned by the call.
+func (a *aborted) abort(i int) {
+	a.mu.Lock()
+	a.abandoned[i] = true
+	done := a.done[i]
+	a.mu.Unlock()
+	if done {
+		a.report(i)
+	}
+}
+
+// returned marks the go routine of element i as returned.
+func (a *aborted) returned(i int) {
+	a.mu.Lock()
+	a.done[i] = true
+	abandoned := a.abandoned[i]
+	a.mu.Unlock()
+	if abandoned {
+		a.report(i)
+	}
+}
+
+// jobErr returns the error held by payload p, if the job has one.
+func jobErr[P any](job Job[P], p P) error {
+	if job.Err == nil {
+		return nil
+	}
+	return job.Err(p)
+}
+
+// fallback returns the payload of element i holding opts.TimeoutFallback, if the job supports it.
+func fallback[P any](opts lists.Options, job Job[P], i int) (P, bool) {
+	if opts.TimeoutFallback == nil || job.Fallback == nil {
+		var p P
+		return p, false
+	}
+	return job.Fallback(i, opts.TimeoutFallback)
+}
+
+type indexedError struct {
+	index int
+	err   error
+}
+
+// Stream runs MapContext in a go routine and writes every payload read from the chan to the returned one, job.Store is not used.
+// With opts.Ordered, payloads are written in the order of the elements, the ones read early are held until then.
+// The returned chan is closed once MapContext returned, its error is then written to the error chan.
+// When the context is done, payloads not yet read from the returned chan are dropped.
+func Stream[P any](ctx context.Context, opts lists.Options, job Job[P]) (<-chan P, <-chan error) {
+	out := make(chan P)
+	errc := make(chan error, 1)
+	send := func(p P) {
+		select {
+		case out <- p:
+		case <-ctx.Done():
+		}
+	}
+
+	var held = make(map[int]P)
+	var next = 0
+	job.Store = func(i int, p P) {
+		if !opts.Ordered {
+			send(p)
+			return
+		}
+		held[i] = p
+		for p, ok := held[next]; ok; p, ok = held[next] {
+			delete(held, next)
+			send(p)
+			next++
+		}
+	}
+	go func() {
+		defer close(errc)
+		err := MapContext(ctx, opts, job)
+		// elements which failed leave the ones after them held
+		for ; len(held) > 0 && next < job.N; next++ {
+			if p, ok := held[next]; ok {
+				delete(held, next)
+				send(p)
+			}
+		}
+		close(out)
+		errc <- err
+	}()
+	return out, errc
+}
+
+type returned struct {
+	index    int
+	panicked bool
+}
+
+// ProtocolError returns the *lists.ProtocolError of the element of the given key wrapped in a *lists.ElementError.
+func ProtocolError(key interface{}, format string, args ...interface{}) error {
+	return &lists.ElementError{
+		Key: key,
+		Err: &lists.ProtocolError{Reason: fmt.Sprintf(format, args...)},
+	}
+}
+
+// As converts v to U, nil converts to the zero value of interface types.
+func As[U any](v interface{}) (U, bool) {
+	u, ok := v.(U)
+	if !ok && v == nil {
+		ok = typeOf[U]().Kind() == reflect.Interface
+	}
+	return u, ok
+}
+
+// Intf returns the key and the value of a [2]interface{} written to the chan by a go routine of the legacy types.
+func Intf[K any, U any](intf [2]interface{}) (K, U, error) {
+	var v U
+	k, ok := As[K](intf[0])
+	if !ok {
+		return k, v, ProtocolError(intf[0], "key written to the chan is a %T, not a %s", intf[0], typeOf[K]())
+	}
+	if v, ok = As[U](intf[1]); !ok {
+		return k, v, ProtocolError(intf[0], "value written to the chan is a %T, not a %s", intf[1], typeOf[U]())
+	}
+	return k, v, nil
+}
+
+func typeOf[U any]() reflect.Type {
+	return reflect.TypeOf((*U)(nil)).Elem()
+}
+
+// recoverPanic recovers a panic of the go routine of element i if opts.OnPanic is not lists.PanicPropagate,
+// and reports it as a *lists.PanicError wrapped in a *lists.ElementError.
+// It must be deferred.
+func recoverPanic(opts lists.Options, key func(int) interface{}, i int, report func(error)) {
+	if opts.OnPanic == lists.PanicPropagate {
+		return
+	}
+	if r := recover(); r != nil {
+		report(panicError(key, i, r))
+	}
+}
+
+func panicError(key func(int) interface{}, i int, r interface{}) error {
+	return &lists.ElementError{
+		Key: key(i),
+		Err: &lists.PanicError{Value: r, Stack: debug.Stack()},
+	}
+}
+
+// callRecover calls call for element i, converting a panic to an error if opts.OnPanic is not lists.PanicPropagate.
+func callRecover[U any](ctx context.Context, opts lists.Options, key func(int) interface{}, i int, call func(context.Context, int) (U, error)) (v U, err error) {
+	if opts.OnPanic != lists.PanicPropagate {
+		defer func() {
+			if r := recover(); r != nil {
+				err = panicError(key, i, r)
+			}
+		}()
+	}
+	return call(ctx, i)
+}
+
+// callTimeout is callRecover giving up on the call once opts.ElementTimeout elapsed, if set.
+// The call is then given up with opts.TimeoutFallback as value, or lists.ErrElementTimeout if it is not a U,
+// its context is cancelled but it is not waited for.
+func callTimeout[U any](ctx context.Context, opts lists.Options, key func(int) interface{}, i int, call func(context.Context, int) (U, error)) (U, error) {
+	if opts.ElementTimeout <= 0 {
+		return callRecover(ctx, opts, key, i, call)
+	}
+	elemCtx, cancel := context.WithTimeout(ctx, opts.ElementTimeout)
+	defer cancel()
+	timer := time.NewTimer(opts.ElementTimeout)
+	defer timer.Stop()
+
+	results := make(chan errResult[U], 1)
+	go func() {
+		v, err := callRecover(elemCtx, opts, key, i, call)
+		results <- errResult[U]{index: i, outcome: lists.Outcome[U]{Value: v, Err: err}}
+	}()
+	select {
+	case r := <-results:
+		// a call failing on the deadline of its context timed out as well
+		if r.outcome.Err == nil || elemCtx.Err() != context.DeadlineExceeded || ctx.Err() != nil {
+			return r.outcome.Value, r.outcome.Err
+		}
+	case <-timer.C:
+	}
+	if v, ok := As[U](opts.TimeoutFallback); ok && opts.TimeoutFallback != nil {
+		return v, nil
+	}
+	var v U
+	return v, &lists.ElementError{Key: key(i), Err: lists.ErrElementTimeout}
+}
+
+// elementObserver passes on the notifications of elements to an Observer, but not the one of completion.
+type elementObserver struct {
+	lists.Observer
+}
+
+func (elementObserver) OnComplete(error) {}
+
+type errResult[U any] struct {
+	index   int
+	outcome lists.Outcome[U]
+}
+
+// MapErr calls call as a go routine for every index in [0, n) and gives every value returned without error to store.
+// With lists.FirstError, the context given to call is cancelled on the first error, which is returned.
+// With lists.JoinErrors, every call is awaited and all the errors are returned joined.
+// Recovered panics are handled as errors returned by call, lists.PanicAbort cancels the context as lists.FirstError does.
+// Failed calls are retried as set by opts.Retry.
+func MapErr[U any](ctx context.Context, n int, opts lists.Options, key func(int) interface{}, call func(context.Context, int) (U, error), store func(int, U)) error {
+	return MapOutcomes(ctx, n, opts, key, call, func(i int, o lists.Outcome[U]) {
+		if o.Err == nil {
+			store(i, o.Value)
+		}
+	})
+}
+
+// MapOutcomes is MapErr giving the outcome of every element done to store, including the failed ones.
+func MapOutcomes[U any](ctx context.Context, n int, opts lists.Options, key func(int) interface{}, call func(context.Context, int) (U, error), store func(int, lists.Outcome[U])) (err error) {
+	mapCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// element timeouts are handled by callTimeout, each attempt having its own
+	var mapOpts = opts
+	mapOpts.ElementTimeout = 0
+	// the observer is told the error returned below once the map is complete
+	if opts.Observer != nil {
+		mapOpts.Observer = elementObserver{opts.Observer}
+		defer func() {
+			opts.Observer.OnComplete(err)
+		}()
+	}
+
+	var errs []error
+	err = MapContext(mapCtx, mapOpts, Job[errResult[U]]{
+		N:   n,
+		Key: key,
+		Launch: func(ctx context.Context, i int, mapChan chan errResult[U]) {
+			var o lists.Outcome[U]
+			for {
+				o.Attempts++
+				o.Value, o.Err = callTimeout(ctx, opts, key, i, call)
+				if o.Err == nil || !opts.Retry.Retry(ctx, o.Attempts, o.Err) {
+					break
+				}
+			}
+			mapChan <- errResult[U]{index: i, outcome: o}
+		},
+		Index: func(r errResult[U]) (int, error) {
+			return r.index, nil
+		},
+		Err: func(r errResult[U]) error {
+			return r.outcome.Err
+		},
+		Store: func(_ int, r errResult[U]) {
+			if r.outcome.Err != nil {
+				errs = append(errs, r.outcome.Err)
+				var panicErr *lists.PanicError
+				if opts.ErrorMode == lists.FirstError || (opts.OnPanic == lists.PanicAbort && errors.As(r.outcome.Err, &panicErr)) {
+					cancel()
+				}
+			}
+			store(r.index, r.outcome)
+		},
+	})
+	if len(errs) == 0 {
+		return err
+	}
+	if opts.ErrorMode == lists.FirstError {
+		return errs[0]
+	}
+	// the context of the map is cancelled when aborting on a panic, only report the caller's one
+	if err := ctx.Err(); err != nil {
+		errs = append(errs, err)
+	}
+	return join(errs...)
+}
+
+// Parallel calls call as a go routine for every index in [0, n) and gives every value returned to store.
+// If workers is higher than 0, no more than workers go routines run at the same time.
+func Parallel[U any](n int, workers int, call func(int) U, store func(int, U)) {
+	MapErr(
+		context.Background(),
+		n,
+		lists.Options{MaxConcurrency: workers},
+		Index,
+		func(_ context.Context, i int) (U, error) {
+			return call(i), nil
+		},
+		store,
+	)
+}
+
+// ReduceParallel reduces c with combine in parallel: c is split into one chunk per worker, each chunk is reduced from identity
+// by its own go routine, then the partial results are combined in pairs, level by level, as a tree.
+// Elements and partial results are always combined left to right, so combine only needs to be associative,
+// and identity must leave any value unchanged when combined with it.
+// If workers is not higher than 0, runtime.GOMAXPROCS(0) workers are used.
+func ReduceParallel[T any](c []T, identity T, combine func(T, T) T, workers int) T {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(c) {
+		workers = len(c)
+	}
+	if workers <= 1 {
+		return fold(c, identity, combine)
+	}
+
+	size := (len(c) + workers - 1) / workers
+	parts := make([]T, (len(c)+size-1)/size)
+	Parallel(len(parts), 0, func(i int) T {
+		end := (i + 1) * size
+		if end > len(c) {
+			end = len(c)
+		}
+		return fold(c[i*size:end], identity, combine)
+	}, func(i int, v T) {
+		parts[i] = v
+	})
+	for len(parts) > 1 {
+		next := make([]T, (len(parts)+1)/2)
+		Parallel(len(next), 0, func(i int) T {
+			if 2*i+1 == len(parts) {
+				return parts[2*i]
+			}
+			return combine(parts[2*i], parts[2*i+1])
+		}, func(i int, v T) {
+			next[i] = v
+		})
+		parts = next
+	}
+	return parts[0]
+}
+
+func fold[T any](c []T, agg T, combine func(T, T) T) T {
+	for _, v := range c {
+		agg = combine(agg, v)
+	}
+	return agg
+}
+
+// Reduce calls launch as a go routine for every index in [0, n), in series.
+// Each go routine must read the current state of the accumulator from agg.Agg and write the next one to agg.Done.
+// Returns the final state of the accumulator.
+func Reduce[A any](n int, launch func(int, *lists.Aggregator[A]), defAgg A) A {
+	agg, _ := ReduceContext(
+		context.Background(),
+		n,
+		lists.Options{},
+		Index,
+		func(_ context.Context, i int, agg *lists.Aggregator[A]) {
+			launch(i, agg)
+		},
+		defAgg,
+	)
+	return agg
+}
+
+// ReduceContext is Reduce with a context given to every go routine.
+// When the context is done, no more go routine is started and ReduceContext returns the current state of the accumulator with ctx.Err().
+// Panics are handled as set by opts.OnPanic, the state of the accumulator is left unchanged by a go routine which panicked.
+// opts.Observer is notified of every step as of an element.
+func ReduceContext[A any](ctx context.Context, n int, opts lists.Options, key func(int) interface{}, launch func(context.Context, int, *lists.Aggregator[A]), defAgg A) (A, error) {
+	state, err := reduceContext(ctx, n, opts, key, launch, defAgg)
+	if opts.Observer != nil {
+		opts.Observer.OnComplete(err)
+	}
+	return state, err
+}
+
+func reduceContext[A any](ctx context.Context, n int, opts lists.Options, key func(int) interface{}, launch func(context.Context, int, *lists.Aggregator[A]), defAgg A) (A, error) {
+	agg := &lists.Aggregator[A]{
+		Done: make(chan A, 1),
+		Agg:  make(chan A, 1),
+	}
+	panics := make(chan error, 1)
+	pool := opts.Pool
+	if pool == nil && n > 0 {
+		pool = lists.NewPool(1, 0)
+		defer pool.Close()
+	}
+
+	var errs []error
+	var state = defAgg
+	// aborts reports the step still running when the reduce stops early once its go routine returns
+	var aborts *aborted
+	var starts []time.Time
+	if opts.Observer != nil {
+		starts = make([]time.Time, n)
+		aborts = newAborted(n, func(i int) {
+			opts.Observer.OnElementDone(key(i), time.Since(starts[i]), lists.ErrElementAborted)
+		})
+	}
+	for i := 0; i < n; i++ {
+		if err := ctx.Err(); err != nil {
+			return state, join(append(errs, err)...)
+		}
+		agg.Agg <- state
+		start := time.Now()
+		if opts.Observer != nil {
+			starts[i] = start
+			opts.Observer.OnStart(key(i))
+		}
+		err := pool.Submit(ctx, func() {
+			if aborts != nil {
+				defer aborts.returned(i)
+			}
+			defer recoverPanic(opts, key, i, func(err error) {
+				panics <- err
+			})
+			launch(ctx, i, agg)
+		})
+		if err != nil {
+			if opts.Observer != nil {
+				opts.Observer.OnElementDone(key(i), time.Since(start), err)
+			}
+			return state, join(append(errs, err)...)
+		}
+		select {
+		case state = <-agg.Done:
+			if opts.Observer != nil {
+				opts.Observer.OnElementDone(key(i), time.Since(start), nil)
+			}
+		case err := <-panics:
+			if opts.Observer != nil {
+				opts.Observer.OnElementDone(key(i), time.Since(start), err)
+			}
+			if opts.OnPanic == lists.PanicAbort {
+				return state, err
+			}
+			errs = append(errs, err)
+		case <-ctx.Done():
+			if aborts != nil {
+				aborts.abort(i)
+			}
+			return state, join(append(errs, ctx.Err())...)
+		}
+		// discard the state if the go routine did not read it
+		select {
+		case <-agg.Agg:
+		default:
+		}
+	}
+	return state, join(errs...)
+}
+
+// join joins errs with errors.Join, a single error is returned as is.
+func join(errs ...error) error {
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,615 @@
+package async
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/francoispqt/lists"
+	"github.com/stretchr/testify/assert"
+)
+
+// intJob is the job of n elements whose go routines write [2]int{index, value} to the chan, storing values to ret.
+func intJob(n int, launch func(context.Context, int, chan [2]int), ret []int) Job[[2]int] {
+	return Job[[2]int]{
+		N:      n,
+		Key:    Index,
+		Launch: launch,
+		Index: func(intf [2]int) (int, error) {
+			return intf[0], nil
+		},
+		Store: func(i int, intf [2]int) {
+			ret[i] = intf[1]
+		},
+	}
+}
+
+func TestConc(t *testing.T) {
+	assert.Equal(t, lists.DEFAULT_CONC, Conc(nil), "no argument should give default concurrency")
+	assert.Equal(t, 10, Conc([]int{10}), "first argument should be the max concurrency")
+}
+
+func TestMap(t *testing.T) {
+	var running, max int32
+	ret := make([]int, 50)
+	Map(5, intJob(50, func(_ context.Context, i int, mapChan chan [2]int) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			m := atomic.LoadInt32(&max)
+			if n <= m || atomic.CompareAndSwapInt32(&max, m, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		mapChan <- [2]int{i, i * 2}
+	}, ret))
+
+	assert.True(t, max <= 5, "concurrency should never exceed 5")
+	for i, v := range ret {
+		assert.Equal(t, i*2, v, "values should be mapped back to their index")
+	}
+}
+
+func TestMapContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var started int32
+	ret := make([]int, 10)
+	start := time.Now()
+	err := MapContext(ctx, lists.Options{MaxConcurrency: 2}, intJob(10, func(ctx context.Context, i int, mapChan chan [2]int) {
+		atomic.AddInt32(&started, 1)
+		if i == 0 {
+			mapChan <- [2]int{i, 1}
+			return
+		}
+		if i == 1 {
+			// leaves time for the payload of element 0 to be read
+			time.Sleep(20 * time.Millisecond)
+			cancel()
+		}
+		// hangs until the context is done
+		<-ctx.Done()
+	}, ret))
+
+	assert.Equal(t, context.Canceled, err, "err should be context.Canceled")
+	assert.True(t, time.Since(start) < time.Second, "MapContext should return promptly")
+	assert.True(t, atomic.LoadInt32(&started) <= 3, "no go routine should be started after cancellation")
+	assert.Equal(t, 1, ret[0], "results received before cancellation should be stored")
+
+	err = MapContext(ctx, lists.Options{}, intJob(10, func(ctx context.Context, i int, mapChan chan [2]int) {
+		t.Error("no go routine should be started with a done context")
+	}, ret))
+	assert.Equal(t, context.Canceled, err, "err should be context.Canceled")
+}
+
+func TestMapErr(t *testing.T) {
+	errBoom := errors.New("boom")
+	ret := make([]int, 20)
+	var called int32
+	err := MapErr(context.Background(), 20, lists.Options{MaxConcurrency: 1}, Index, func(ctx context.Context, i int) (int, error) {
+		atomic.AddInt32(&called, 1)
+		if i == 2 {
+			return 0, errBoom
+		}
+		return i * 2, nil
+	}, func(i int, v int) {
+		ret[i] = v
+	})
+	assert.Equal(t, errBoom, err, "first error should be returned")
+	assert.Equal(t, int32(3), atomic.LoadInt32(&called), "no go routine should be started after the first error")
+	assert.Equal(t, 2, ret[1], "values returned before the error should be stored")
+
+	err = MapErr(context.Background(), 4, lists.Options{ErrorMode: lists.JoinErrors}, Index, func(ctx context.Context, i int) (int, error) {
+		if i%2 == 0 {
+			return 0, fmt.Errorf("error %d", i)
+		}
+		return i, nil
+	}, func(i int, v int) {
+		ret[i] = v
+	})
+	assert.NotNil(t, err, "err should not be nil")
+	assert.Contains(t, err.Error(), "error 0", "joined error should contain error 0")
+	assert.Contains(t, err.Error(), "error 2", "joined error should contain error 2")
+	assert.Equal(t, 3, ret[3], "values returned without error should be stored")
+
+	var errOdd = errors.New("odd")
+	err = MapErr(context.Background(), 4, lists.Options{ErrorMode: lists.JoinErrors}, Index, func(ctx context.Context, i int) (int, error) {
+		if i%2 == 0 {
+			return 0, &lists.ElementError{Key: i, Err: errBoom}
+		}
+		return 0, fmt.Errorf("element %d: %w", i, errOdd)
+	}, func(i int, v int) {})
+	assert.ErrorIs(t, err, errBoom, "errors.Is should find an error in the joined errors")
+	assert.ErrorIs(t, err, errOdd, "errors.Is should find every error in the joined errors")
+	var elemErr *lists.ElementError
+	assert.ErrorAs(t, err, &elemErr, "errors.As should find an *ElementError in the joined errors")
+
+	err = MapErr(context.Background(), 4, lists.Options{}, Index, func(ctx context.Context, i int) (int, error) {
+		return i, nil
+	}, func(i int, v int) {})
+	assert.Nil(t, err, "err should be nil")
+}
+
+func TestReduce(t *testing.T) {
+	ret := Reduce(0, func(i int, agg *lists.Aggregator[int]) {}, 3)
+	assert.Equal(t, 3, ret, "reducing nothing should return the default accumulator")
+
+	ret = Reduce(4, func(i int, agg *lists.Aggregator[int]) {
+		agg.Done <- <-agg.Agg + i
+	}, 0)
+	assert.Equal(t, 6, ret, "sum of indexes should be 6")
+}
+
+func TestMapContextPanic(t *testing.T) {
+	ret := make([]int, 4)
+	launch := func(ctx context.Context, i int, mapChan chan [2]int) {
+		if i == 1 {
+			panic("boom")
+		}
+		mapChan <- [2]int{i, i}
+	}
+
+	err := MapContext(context.Background(), lists.Options{OnPanic: lists.PanicContinue}, intJob(4, launch, ret))
+	var elemErr *lists.ElementError
+	var panicErr *lists.PanicError
+	assert.True(t, errors.As(err, &elemErr), "err should be a *lists.ElementError")
+	assert.Equal(t, 1, elemErr.Key, "key should be the index of the element which panicked")
+	assert.True(t, errors.As(err, &panicErr), "err should wrap a *lists.PanicError")
+	assert.Equal(t, "boom", panicErr.Value, "value should be the value passed to panic")
+	assert.NotEmpty(t, panicErr.Stack, "stack trace should be attached")
+	assert.Equal(t, []int{0, 0, 2, 3}, ret, "other elements should be mapped")
+
+	err = MapContext(context.Background(), lists.Options{MaxConcurrency: 1, OnPanic: lists.PanicAbort}, intJob(4, launch, ret))
+	assert.True(t, errors.As(err, &panicErr), "err should wrap a *lists.PanicError")
+
+	_, err = callRecover(context.Background(), lists.Options{OnPanic: lists.PanicContinue}, Index, 3, func(ctx context.Context, i int) (int, error) {
+		panic("boom")
+	})
+	assert.True(t, errors.As(err, &panicErr), "call should recover the panic")
+}
+
+func TestMapErrPanic(t *testing.T) {
+	var called int32
+	err := MapErr(context.Background(), 10, lists.Options{MaxConcurrency: 1, ErrorMode: lists.JoinErrors, OnPanic: lists.PanicAbort}, Index, func(ctx context.Context, i int) (int, error) {
+		atomic.AddInt32(&called, 1)
+		if i == 1 {
+			panic("boom")
+		}
+		return i, nil
+	}, func(i int, v int) {})
+
+	var panicErr *lists.PanicError
+	assert.True(t, errors.As(err, &panicErr), "err should wrap a *lists.PanicError")
+	assert.False(t, errors.Is(err, context.Canceled), "err should not include the internal cancellation")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&called), "no go routine should be started after the panic")
+}
+
+func TestReduceContext(t *testing.T) {
+	launch := func(ctx context.Context, i int, agg *lists.Aggregator[int]) {
+		state := <-agg.Agg
+		if i == 1 {
+			panic("boom")
+		}
+		agg.Done <- state + i
+	}
+
+	ret, err := ReduceContext(context.Background(), 4, lists.Options{OnPanic: lists.PanicContinue}, Index, launch, 0)
+	var panicErr *lists.PanicError
+	assert.True(t, errors.As(err, &panicErr), "err should wrap a *lists.PanicError")
+	assert.Equal(t, 5, ret, "state should be left unchanged by the go routine which panicked")
+
+	ret, err = ReduceContext(context.Background(), 4, lists.Options{OnPanic: lists.PanicAbort}, Index, launch, 0)
+	assert.True(t, errors.As(err, &panicErr), "err should wrap a *lists.PanicError")
+	assert.Equal(t, 0, ret, "state should be the one before the panic")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ret, err = ReduceContext(ctx, 4, lists.Options{}, Index, func(ctx context.Context, i int, agg *lists.Aggregator[int]) {
+		if i == 2 {
+			cancel()
+			<-ctx.Done()
+			return
+		}
+		agg.Done <- <-agg.Agg + 1
+	}, 0)
+	assert.Equal(t, context.Canceled, err, "err should be context.Canceled")
+	assert.Equal(t, 2, ret, "state should be the one before cancellation")
+}
+
+func TestMapContextPool(t *testing.T) {
+	pool := lists.NewPool(2, 0)
+	defer pool.Close()
+
+	var running, max int32
+	launch := func(ctx context.Context, i int, mapChan chan [2]int) {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			m := atomic.LoadInt32(&max)
+			if n <= m || atomic.CompareAndSwapInt32(&max, m, n) {
+				break
+			}
+		}
+		time.Sleep(time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		mapChan <- [2]int{i, i}
+	}
+
+	// two calls sharing the pool never run more than 2 go routines at the same time
+	errs := make(chan error, 2)
+	for c := 0; c < 2; c++ {
+		go func() {
+			errs <- MapContext(context.Background(), lists.Options{Pool: pool}, intJob(20, launch, make([]int, 20)))
+		}()
+	}
+	assert.Nil(t, <-errs, "err should be nil")
+	assert.Nil(t, <-errs, "err should be nil")
+	assert.True(t, atomic.LoadInt32(&max) <= 2, "concurrency should never exceed the pool workers")
+
+	pool.Close()
+	err := MapContext(context.Background(), lists.Options{Pool: pool}, intJob(2, launch, make([]int, 2)))
+	assert.Equal(t, lists.ErrPoolClosed, err, "err should be lists.ErrPoolClosed")
+}
+
+func TestMapContextDebug(t *testing.T) {
+	var protoErr *lists.ProtocolError
+	var elemErr *lists.ElementError
+	opts := lists.Options{Debug: true}
+
+	// returning without writing to the chan would hang without debug mode
+	lists.DebugGrace = 50 * time.Millisecond
+	defer func() {
+		lists.DebugGrace = 0
+	}()
+	err := MapContext(context.Background(), opts, intJob(4, func(_ context.Context, i int, mapChan chan [2]int) {
+		if i == 2 {
+			return
+		}
+		mapChan <- [2]int{i, i}
+	}, make([]int, 4)))
+	assert.True(t, errors.As(err, &protoErr), "err should wrap a *lists.ProtocolError")
+	assert.True(t, errors.As(err, &elemErr), "err should be a *lists.ElementError")
+	assert.Equal(t, 2, elemErr.Key, "key should be the element which did not write to the chan")
+	assert.Equal(t, "lists: element 2: chan protocol: go routine returned without writing to the chan", err.Error())
+
+	// writing from another go routine within the grace period is fine
+	ret := make([]int, 4)
+	err = MapContext(context.Background(), opts, intJob(4, func(_ context.Context, i int, mapChan chan [2]int) {
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			mapChan <- [2]int{i, i * 2}
+		}()
+	}, ret))
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, []int{0, 2, 4, 6}, ret, "values should be mapped")
+
+	// without a grace, a write from another go routine is awaited however long it takes
+	lists.DebugGrace = 0
+	ret = make([]int, 2)
+	err = MapContext(context.Background(), opts, intJob(2, func(_ context.Context, i int, mapChan chan [2]int) {
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			mapChan <- [2]int{i, i + 1}
+		}()
+	}, ret))
+	assert.Nil(t, err, "a late write should not be reported without a grace")
+	assert.Equal(t, []int{1, 2}, ret, "values should be mapped")
+	lists.DebugGrace = 50 * time.Millisecond
+
+	err = MapContext(context.Background(), opts, intJob(4, func(_ context.Context, i int, mapChan chan [2]int) {
+		mapChan <- [2]int{0, i}
+	}, make([]int, 4)))
+	assert.True(t, errors.As(err, &elemErr), "err should be a *lists.ElementError")
+	assert.Equal(t, 0, elemErr.Key, "key should be the element written twice")
+	assert.Contains(t, err.Error(), "more than once")
+
+	// indexes out of range are reported without debug mode
+	err = MapContext(context.Background(), lists.Options{}, intJob(4, func(_ context.Context, i int, mapChan chan [2]int) {
+		mapChan <- [2]int{i + 1, i}
+	}, make([]int, 4)))
+	assert.True(t, errors.As(err, &elemErr), "err should be a *lists.ElementError")
+	assert.Equal(t, 4, elemErr.Key, "key should be the index out of range")
+	assert.Contains(t, err.Error(), "index out of range [0, 4)")
+
+	assert.Panics(t, func() {
+		Map(0, intJob(1, func(_ context.Context, i int, mapChan chan [2]int) {
+			mapChan <- [2]int{-1, i}
+		}, make([]int, 1)))
+	}, "Map should panic with the error")
+}
+
+func TestIntf(t *testing.T) {
+	k, v, err := Intf[int, string]([2]interface{}{1, "a"})
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, 1, k)
+	assert.Equal(t, "a", v)
+
+	_, v2, err := Intf[string, interface{}]([2]interface{}{"a", nil})
+	assert.Nil(t, err, "nil should be a valid interface{}")
+	assert.Nil(t, v2)
+
+	_, _, err = Intf[int, string]([2]interface{}{"1", "a"})
+	assert.EqualError(t, err, "lists: element 1: chan protocol: key written to the chan is a string, not a int")
+
+	_, _, err = Intf[int, string]([2]interface{}{1, 2})
+	assert.EqualError(t, err, "lists: element 1: chan protocol: value written to the chan is a int, not a string")
+
+	_, _, err = Intf[int, string]([2]interface{}{1, nil})
+	assert.NotNil(t, err, "nil should not be a valid string")
+}
+
+func TestReduceParallel(t *testing.T) {
+	c := make([]int, 1001)
+	for i := range c {
+		c[i] = i
+	}
+	add := func(a, b int) int {
+		return a + b
+	}
+	for _, workers := range []int{0, 1, 3, 8, 2000} {
+		assert.Equal(t, 500500, ReduceParallel(c, 0, add, workers), "sum should be 500500 with %d workers", workers)
+	}
+	assert.Equal(t, 7, ReduceParallel(nil, 7, add, 4), "reducing nothing should return identity")
+
+	// combine is associative but not commutative, order must be kept
+	s := []string{"a", "b", "c", "d", "e", "f", "g"}
+	concat := func(a, b string) string {
+		return a + b
+	}
+	for workers := 1; workers <= 8; workers++ {
+		assert.Equal(t, "abcdefg", ReduceParallel(s, "", concat, workers), "elements should be combined in order with %d workers", workers)
+	}
+}
+
+func TestStream(t *testing.T) {
+	// element i completes after (5-i) * 10ms, in reverse order
+	launch := func(_ context.Context, i int, mapChan chan [2]int) {
+		time.Sleep(time.Duration(5-i) * 10 * time.Millisecond)
+		mapChan <- [2]int{i, i * 2}
+	}
+
+	out, errc := Stream(context.Background(), lists.Options{}, intJob(5, launch, nil))
+	var got []int
+	for p := range out {
+		got = append(got, p[0])
+	}
+	assert.Nil(t, <-errc, "err should be nil")
+	assert.Equal(t, []int{4, 3, 2, 1, 0}, got, "payloads should be yielded as they complete")
+
+	out, errc = Stream(context.Background(), lists.Options{Ordered: true}, intJob(5, launch, nil))
+	got = nil
+	for p := range out {
+		got = append(got, p[0])
+	}
+	assert.Nil(t, <-errc, "err should be nil")
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, got, "payloads should be yielded in order")
+
+	// payloads after an element which panicked are yielded once the others are done
+	out, errc = Stream(context.Background(), lists.Options{Ordered: true, OnPanic: lists.PanicContinue}, intJob(3, func(_ context.Context, i int, mapChan chan [2]int) {
+		if i == 0 {
+			panic("boom")
+		}
+		mapChan <- [2]int{i, i}
+	}, nil))
+	got = nil
+	for p := range out {
+		got = append(got, p[0])
+	}
+	var panicErr *lists.PanicError
+	assert.True(t, errors.As(<-errc, &panicErr), "err should wrap a *lists.PanicError")
+	assert.Equal(t, []int{1, 2}, got, "payloads should be yielded in order")
+}
+
+func TestMapOutcomes(t *testing.T) {
+	errTransient := errors.New("transient")
+	errFatal := errors.New("fatal")
+	var calls [3]int32
+	ret := make([]lists.Outcome[int], 3)
+	err := MapOutcomes(context.Background(), 3, lists.Options{
+		ErrorMode: lists.JoinErrors,
+		Retry: &lists.RetryPolicy{
+			MaxAttempts: 4,
+			Backoff:     time.Millisecond,
+			Retryable: func(err error) bool {
+				return err != errFatal
+			},
+		},
+	}, Index, func(_ context.Context, i int) (int, error) {
+		n := atomic.AddInt32(&calls[i], 1)
+		switch {
+		case i == 1 && n < 3:
+			return 0, errTransient
+		case i == 2:
+			return 0, errFatal
+		}
+		return i * 10, nil
+	}, func(i int, o lists.Outcome[int]) {
+		ret[i] = o
+	})
+
+	assert.True(t, errors.Is(err, errFatal), "err should be the error of the element which failed")
+	assert.False(t, errors.Is(err, errTransient), "errors of attempts retried successfully should not be returned")
+	assert.Equal(t, lists.Outcome[int]{Value: 0, Attempts: 1}, ret[0], "element 0 should succeed at once")
+	assert.Equal(t, lists.Outcome[int]{Value: 10, Attempts: 3}, ret[1], "element 1 should succeed on the third attempt")
+	assert.Equal(t, lists.Outcome[int]{Err: errFatal, Attempts: 1}, ret[2], "element 2 should not be retried")
+}
+
+func TestMapContextTimeout(t *testing.T) {
+	// element 1 hangs until its context is done, element 2 ignores it
+	launch := func(ctx context.Context, i int, mapChan chan [2]int) {
+		switch i {
+		case 1:
+			<-ctx.Done()
+		case 2:
+			time.Sleep(200 * time.Millisecond)
+		}
+		mapChan <- [2]int{i, i + 1}
+	}
+
+	ret := make([]int, 4)
+	start := time.Now()
+	err := MapContext(context.Background(), lists.Options{MaxConcurrency: 2, ElementTimeout: 30 * time.Millisecond}, intJob(4, launch, ret))
+	assert.True(t, time.Since(start) < 150*time.Millisecond, "MapContext should not wait for elements which timed out")
+	assert.True(t, errors.Is(err, lists.ErrElementTimeout), "err should be lists.ErrElementTimeout")
+	var elemErr *lists.ElementError
+	assert.True(t, errors.As(err, &elemErr), "err should be a *lists.ElementError")
+	assert.Equal(t, []int{1, 0, 0, 4}, ret, "elements which timed out should be left to their zero value")
+
+	job := intJob(4, launch, ret)
+	job.Fallback = func(i int, v interface{}) ([2]int, bool) {
+		n, ok := v.(int)
+		return [2]int{i, n}, ok
+	}
+	err = MapContext(context.Background(), lists.Options{ElementTimeout: 30 * time.Millisecond, TimeoutFallback: -1}, job)
+	assert.Nil(t, err, "err should be nil with a fallback")
+	assert.Equal(t, []int{1, -1, -1, 4}, ret, "elements which timed out should be set to the fallback")
+
+	// element 0 ignores its context, the only worker of the Pool is released once it timed out
+	release := make(chan struct{})
+	defer close(release)
+	pool := lists.NewPool(1, 0)
+	defer pool.Close()
+	ret = make([]int, 3)
+	start = time.Now()
+	err = MapContext(context.Background(), lists.Options{MaxConcurrency: 1, Pool: pool, ElementTimeout: 30 * time.Millisecond}, intJob(3, func(ctx context.Context, i int, mapChan chan [2]int) {
+		if i == 0 {
+			<-release
+		}
+		mapChan <- [2]int{i, i + 1}
+	}, ret))
+	assert.True(t, time.Since(start) < 150*time.Millisecond, "elements which timed out should not hold a worker of the Pool")
+	assert.True(t, errors.Is(err, lists.ErrElementTimeout), "err should be lists.ErrElementTimeout")
+	assert.Equal(t, []int{0, 2, 3}, ret, "elements after the one which timed out should be run by the Pool")
+}
+
+func TestMapErrTimeout(t *testing.T) {
+	ret := make([]int, 3)
+	call := func(ctx context.Context, i int) (int, error) {
+		if i == 1 {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}
+		if i == 2 {
+			time.Sleep(200 * time.Millisecond)
+		}
+		return i, nil
+	}
+	store := func(i int, v int) {
+		ret[i] = v
+	}
+
+	start := time.Now()
+	err := MapErr(context.Background(), 3, lists.Options{ErrorMode: lists.JoinErrors, ElementTimeout: 30 * time.Millisecond}, Index, call, store)
+	assert.True(t, time.Since(start) < 150*time.Millisecond, "MapErr should not wait for elements which timed out")
+	assert.True(t, errors.Is(err, lists.ErrElementTimeout), "err should be lists.ErrElementTimeout")
+	assert.False(t, errors.Is(err, context.DeadlineExceeded), "the deadline of the element should be reported as a timeout")
+
+	err = MapErr(context.Background(), 3, lists.Options{ElementTimeout: 30 * time.Millisecond, TimeoutFallback: 7}, Index, call, store)
+	assert.Nil(t, err, "err should be nil with a fallback")
+	assert.Equal(t, []int{0, 7, 7}, ret, "elements which timed out should be set to the fallback")
+}
+
+// recorder is an Observer recording the notifications it gets.
+type recorder struct {
+	mu       sync.Mutex
+	started  []interface{}
+	done     map[interface{}]error
+	complete []error
+}
+
+func (r *recorder) OnStart(key interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.started = append(r.started, key)
+}
+
+func (r *recorder) OnElementDone(key interface{}, d time.Duration, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.done == nil {
+		r.done = map[interface{}]error{}
+	}
+	r.done[key] = err
+}
+
+func (r *recorder) OnComplete(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.complete = append(r.complete, err)
+}
+
+// doneErr returns the error element key was reported done with, if it was.
+func (r *recorder) doneErr(key interface{}) (error, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	err, ok := r.done[key]
+	return err, ok
+}
+
+func TestMapContextObserver(t *testing.T) {
+	rec := &recorder{}
+	err := MapContext(context.Background(), lists.Options{MaxConcurrency: 1, OnPanic: lists.PanicContinue, Observer: rec}, intJob(3, func(_ context.Context, i int, mapChan chan [2]int) {
+		if i == 1 {
+			panic("boom")
+		}
+		mapChan <- [2]int{i, i}
+	}, make([]int, 3)))
+
+	var panicErr *lists.PanicError
+	assert.Equal(t, []interface{}{0, 1, 2}, rec.started, "every element should be started")
+	assert.Nil(t, rec.done[0], "element 0 should succeed")
+	assert.True(t, errors.As(rec.done[1], &panicErr), "element 1 should fail with its panic")
+	assert.Len(t, rec.done, 3, "every element should be done")
+	assert.Equal(t, []error{err}, rec.complete, "completion should be notified once with the error returned")
+
+	rec = &recorder{}
+	errBoom := errors.New("boom")
+	err = MapErr(context.Background(), 2, lists.Options{ErrorMode: lists.JoinErrors, Observer: rec}, Index, func(_ context.Context, i int) (int, error) {
+		if i == 0 {
+			return 0, errBoom
+		}
+		return i, nil
+	}, func(int, int) {})
+	assert.Equal(t, errBoom, rec.done[0], "element 0 should fail with its error")
+	assert.Nil(t, rec.done[1], "element 1 should succeed")
+	assert.Equal(t, []error{errBoom}, rec.complete, "completion should be notified once with the error returned")
+	assert.Equal(t, errBoom, err)
+}
+
+func TestObserverAborted(t *testing.T) {
+	rec := &recorder{}
+	release := make(chan struct{})
+	errBoom := errors.New("boom")
+	err := MapErr(context.Background(), 2, lists.Options{Observer: rec}, Index, func(_ context.Context, i int) (int, error) {
+		if i == 0 {
+			return 0, errBoom
+		}
+		<-release
+		return i, nil
+	}, func(int, int) {})
+	assert.Equal(t, errBoom, err)
+	_, ok := rec.doneErr(1)
+	assert.False(t, ok, "element 1 should not be done while its go routine runs")
+	close(release)
+	assert.Eventually(t, func() bool {
+		err, ok := rec.doneErr(1)
+		return ok && err == lists.ErrElementAborted
+	}, time.Second, time.Millisecond, "element 1 should be aborted once its go routine returned")
+
+	rec = &recorder{}
+	release = make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err = ReduceContext(ctx, 2, lists.Options{Observer: rec}, Index, func(_ context.Context, i int, agg *lists.Aggregator[int]) {
+		cancel()
+		<-release
+		agg.Done <- <-agg.Agg + i
+	}, 0)
+	assert.Equal(t, context.Canceled, err)
+	close(release)
+	assert.Eventually(t, func() bool {
+		err, ok := rec.doneErr(0)
+		return ok && err == lists.ErrElementAborted
+	}, time.Second, time.Millisecond, "the step running should be aborted once its go routine returned")
+}
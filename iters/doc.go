@@ -0,0 +1,11 @@
+// Package iters provides Iter, a lazy iterator built on iter.Seq: its methods chain steps
+// which are only run, one element at a time, when the Iter is ranged over or collected.
+//
+// The slice and map types of the slices and maps packages return Iters from their Values methods:
+//
+//	for words := range iters.Chunk(slices.StringSlice(lines).Values().Filter(notEmpty), 100) {
+//		index(words)
+//	}
+//
+// The package requires go 1.23, it is empty when built with earlier versions.
+package iters
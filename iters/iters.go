@@ -0,0 +1,141 @@
+//go:build go1.23
+
+package iters
+
+import (
+	"iter"
+)
+
+// Iter is a lazy sequence of elements of type T, it can be ranged over directly.
+// An iter.Seq is converted to an Iter with Iter[T](seq).
+type Iter[T any] iter.Seq[T]
+
+// Seq returns the Iter as an iter.Seq, for the functions of the standard library.
+func (it Iter[T]) Seq() iter.Seq[T] {
+	return iter.Seq[T](it)
+}
+
+// Map method returns an Iter yielding the results of calling a provided func on every element.
+// To map to another type, see MapTo.
+func (it Iter[T]) Map(cb func(T) T) Iter[T] {
+	return MapTo(it, cb)
+}
+
+// Filter method returns an Iter yielding the elements that pass the test implemented by the provided func.
+func (it Iter[T]) Filter(cb func(T) bool) Iter[T] {
+	return func(yield func(T) bool) {
+		for v := range it {
+			if cb(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Take method returns an Iter yielding the first n elements, the rest of the elements are never computed.
+func (it Iter[T]) Take(n int) Iter[T] {
+	return func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+		i := 0
+		for v := range it {
+			if !yield(v) {
+				return
+			}
+			i++
+			if i == n {
+				return
+			}
+		}
+	}
+}
+
+// Skip method returns an Iter yielding the elements after the first n ones.
+func (it Iter[T]) Skip(n int) Iter[T] {
+	return func(yield func(T) bool) {
+		i := 0
+		for v := range it {
+			if i < n {
+				i++
+				continue
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Collect method runs the Iter and returns its elements in a slice, which can be assigned to any slice type
+// of the slices package: var s slices.IntSlice = it.Collect().
+func (it Iter[T]) Collect() []T {
+	var ret = make([]T, 0)
+	for v := range it {
+		ret = append(ret, v)
+	}
+	return ret
+}
+
+// Reduce method runs the Iter and applies a func against an accumulator and each element (from first to last) to reduce it to a single value.
+// To reduce to another type, see ReduceTo.
+func (it Iter[T]) Reduce(cb func(T, T) T, agg T) T {
+	return ReduceTo(it, cb, agg)
+}
+
+// MapTo func returns an Iter yielding the results of calling a provided func on every element of it.
+func MapTo[T any, U any](it Iter[T], cb func(T) U) Iter[U] {
+	return func(yield func(U) bool) {
+		for v := range it {
+			if !yield(cb(v)) {
+				return
+			}
+		}
+	}
+}
+
+// Chunk func returns an Iter yielding the elements in slices of size elements, the last one may be shorter.
+// It is a func and not a method as methods cannot yield another instantiation of Iter.
+// Chunk panics if size is lower than 1.
+func Chunk[T any](it Iter[T], size int) Iter[[]T] {
+	if size < 1 {
+		panic("iters: Chunk given size < 1")
+	}
+	return func(yield func([]T) bool) {
+		chunk := make([]T, 0, size)
+		for v := range it {
+			chunk = append(chunk, v)
+			if len(chunk) == size {
+				if !yield(chunk) {
+					return
+				}
+				chunk = make([]T, 0, size)
+			}
+		}
+		if len(chunk) > 0 {
+			yield(chunk)
+		}
+	}
+}
+
+// ReduceTo func runs it and applies a func against an accumulator and each element (from first to last) to reduce it to a single value of any type.
+func ReduceTo[T any, A any](it Iter[T], cb func(T, A) A, agg A) A {
+	for v := range it {
+		agg = cb(v, agg)
+	}
+	return agg
+}
+
+// Zip func returns an iter.Seq2 yielding the elements of a and b in pairs, it stops with the shortest one.
+func Zip[T any, U any](a Iter[T], b Iter[U]) iter.Seq2[T, U] {
+	return func(yield func(T, U) bool) {
+		next, stop := iter.Pull(iter.Seq[U](b))
+		defer stop()
+		for v := range a {
+			u, ok := next()
+			if !ok || !yield(v, u) {
+				return
+			}
+		}
+	}
+}
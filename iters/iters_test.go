@@ -0,0 +1,63 @@
+//go:build go1.23
+
+package iters
+
+import (
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func count(n int, computed *int) Iter[int] {
+	return func(yield func(int) bool) {
+		for i := 0; i < n; i++ {
+			*computed++
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+func TestIter(t *testing.T) {
+	var computed int
+	it := count(10, &computed).Filter(func(v int) bool {
+		return v%2 == 0
+	}).Map(func(v int) int {
+		return v * 10
+	})
+	assert.Equal(t, 0, computed, "nothing should be computed before the Iter is run")
+	assert.Equal(t, []int{0, 20, 40, 60, 80}, it.Collect(), "Filter and Map should be applied in order")
+
+	computed = 0
+	assert.Equal(t, []int{2, 3, 4}, count(100, &computed).Skip(2).Take(3).Collect(), "Skip and Take should select the elements")
+	assert.Equal(t, 5, computed, "the elements after Take should not be computed")
+	assert.Empty(t, count(10, &computed).Take(0).Collect(), "Take(0) should yield nothing")
+
+	assert.Equal(t, [][]int{{0, 1, 2}, {3, 4, 5}, {6}}, Chunk(count(7, &computed), 3).Collect(), "Chunk should group elements by 3")
+	assert.Equal(t, [][]int{{0, 1}}, Chunk(count(7, &computed), 2).Take(1).Collect(), "Chunk should stop when the consumer stops")
+	assert.Panics(t, func() { Chunk(count(1, &computed), 0) }, "size < 1 should panic")
+
+	assert.Equal(t, 45, count(10, &computed).Reduce(func(v int, agg int) int {
+		return agg + v
+	}, 0), "sum should be 45")
+	assert.Equal(t, "012", ReduceTo(count(3, &computed), func(v int, agg string) string {
+		return agg + strconv.Itoa(v)
+	}, ""), "ReduceTo should reduce to a string")
+	assert.Equal(t, []string{"0", "1"}, MapTo(count(2, &computed), strconv.Itoa).Collect(), "MapTo should convert every element")
+
+	var zipped []string
+	for i, s := range Zip(count(5, &computed), Iter[string](slices.Values([]string{"a", "b", "c"}))) {
+		zipped = append(zipped, strconv.Itoa(i)+s)
+	}
+	assert.Equal(t, []string{"0a", "1b", "2c"}, zipped, "Zip should stop with the shortest Iter")
+
+	var ranged []int
+	for v := range count(3, &computed) {
+		ranged = append(ranged, v)
+	}
+	assert.Equal(t, []int{0, 1, 2}, ranged, "an Iter should be ranged over")
+	assert.Equal(t, []int{0, 1, 2}, slices.Collect(count(3, &computed).Seq()), "Seq should work with the standard library")
+}
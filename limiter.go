@@ -0,0 +1,96 @@
+package lists
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Clock gives the time to a Limiter, it can be replaced by a fake one in tests.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// Limiter is a token bucket limiting the rate at which async methods start their go routines.
+// The bucket holds up to burst tokens and is refilled with rate tokens per second, each go routine takes one.
+// Async methods wait for a token from the Limiter given in Options.RateLimit,
+// a Limiter shared by several calls bounds their rate as a whole.
+type Limiter struct {
+	rate  float64
+	burst float64
+	clock Clock
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewLimiter returns a Limiter of rate tokens per second with a bucket of burst tokens, starting full.
+// If burst is lower than 1, the bucket holds 1 token. If rate is not higher than 0, the Limiter does not limit.
+func NewLimiter(rate float64, burst int) *Limiter {
+	return NewLimiterClock(rate, burst, systemClock{})
+}
+
+// NewLimiterClock is NewLimiter using clock to tell the time and to wait.
+func NewLimiterClock(rate float64, burst int, clock Clock) *Limiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &Limiter{
+		rate:   rate,
+		burst:  float64(burst),
+		clock:  clock,
+		tokens: float64(burst),
+		last:   clock.Now(),
+	}
+}
+
+// Wait takes a token from the bucket, waiting until one is available or until the context is done.
+// Returns ctx.Err() if the context is done first.
+func (l *Limiter) Wait(ctx context.Context) error {
+	if l.rate <= 0 {
+		return ctx.Err()
+	}
+	for {
+		wait, ok := l.take()
+		if ok {
+			return nil
+		}
+		select {
+		case <-l.clock.After(wait):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// take takes a token if one is available, otherwise it returns how long until the next one.
+func (l *Limiter) take() (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens += elapsed.Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+	}
+	l.last = now
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0, true
+	}
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second)), false
+}
@@ -0,0 +1,94 @@
+package lists
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClock is a Clock whose time only moves with Advance.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []fakeWaiter
+}
+
+type fakeWaiter struct {
+	at time.Time
+	c  chan time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	w := fakeWaiter{at: c.now.Add(d), c: make(chan time.Time, 1)}
+	c.waiters = append(c.waiters, w)
+	return w.c
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	var waiters []fakeWaiter
+	for _, w := range c.waiters {
+		if w.at.After(c.now) {
+			waiters = append(waiters, w)
+			continue
+		}
+		w.c <- c.now
+	}
+	c.waiters = waiters
+}
+
+func TestLimiter(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	limiter := NewLimiterClock(2, 3, clock)
+
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, limiter.Wait(context.Background()), "the burst should be available at once")
+	}
+
+	done := make(chan error)
+	go func() {
+		done <- limiter.Wait(context.Background())
+	}()
+	select {
+	case <-done:
+		t.Fatal("Wait should block once the bucket is empty")
+	case <-time.After(20 * time.Millisecond):
+	}
+	clock.Advance(250 * time.Millisecond)
+	select {
+	case <-done:
+		t.Fatal("half a token should not be enough")
+	case <-time.After(20 * time.Millisecond):
+	}
+	clock.Advance(250 * time.Millisecond)
+	select {
+	case err := <-done:
+		assert.Nil(t, err, "err should be nil")
+	case <-time.After(time.Second):
+		t.Fatal("a token should be available after 500ms")
+	}
+
+	// the bucket never holds more than burst tokens
+	clock.Advance(time.Hour)
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, limiter.Wait(context.Background()), "the burst should be available at once")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, context.Canceled, limiter.Wait(ctx), "err should be context.Canceled")
+
+	assert.Nil(t, NewLimiter(0, 0).Wait(context.Background()), "a rate of 0 should not limit")
+}
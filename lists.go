@@ -1,8 +1,29 @@
 package lists
 
+// DEFAULT_CONC is the default max concurrency of async methods, 0 means no limit.
 const DEFAULT_CONC = 0
 
-type AsyncAggregator struct {
-	Agg  chan interface{}
-	Done chan interface{}
+// Aggregator is the accumulator given to the go routines of ReduceAsync methods.
+// The current state of the accumulator must be read from Agg and the next state written to Done.
+type Aggregator[A any] struct {
+	Agg  chan A
+	Done chan A
+}
+
+// AsyncAggregator is the untyped Aggregator used by the ReduceAsync methods of the legacy types.
+type AsyncAggregator = Aggregator[interface{}]
+
+// Result is the payload written to the chan by the go routines of generic MapAsync methods.
+// Key is the index (or the map key) at which Value will be stored.
+type Result[K any, V any] struct {
+	Key   K
+	Value V
+}
+
+// Outcome is the outcome of an element of the MapAsyncRetry methods.
+// Err is the error of the last attempt, nil if the element succeeded, and Attempts the number of times the func was called.
+type Outcome[V any] struct {
+	Value    V
+	Err      error
+	Attempts int
 }
@@ -0,0 +1,132 @@
+//go:build go1.23
+
+package maps
+
+import (
+	"iter"
+
+	"github.com/francoispqt/lists/iters"
+)
+
+// All method returns an iter.Seq2 yielding the keys and values of the map, in no particular order.
+func (c Map[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range c {
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// Keys method returns a lazy iters.Iter yielding the keys of the map, in no particular order.
+func (c Map[K, V]) Keys() iters.Iter[K] {
+	return func(yield func(K) bool) {
+		for k := range c {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// Values method returns a lazy iters.Iter yielding the values of the map, in no particular order.
+func (c Map[K, V]) Values() iters.Iter[V] {
+	return func(yield func(V) bool) {
+		for _, v := range c {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// All method returns an iter.Seq2 yielding the keys and values of the map, in no particular order.
+func (c MapInterfaceInterface) All() iter.Seq2[interface{}, interface{}] {
+	return Map[interface{}, interface{}](c).All()
+}
+
+// Keys method returns a lazy iters.Iter yielding the keys of the map, in no particular order.
+func (c MapInterfaceInterface) Keys() iters.Iter[interface{}] {
+	return Map[interface{}, interface{}](c).Keys()
+}
+
+// Values method returns a lazy iters.Iter yielding the values of the map, in no particular order.
+func (c MapInterfaceInterface) Values() iters.Iter[interface{}] {
+	return Map[interface{}, interface{}](c).Values()
+}
+
+// All method returns an iter.Seq2 yielding the keys and values of the map, in no particular order.
+func (c MapStringString) All() iter.Seq2[string, string] {
+	return Map[string, string](c).All()
+}
+
+// Keys method returns a lazy iters.Iter yielding the keys of the map, in no particular order.
+func (c MapStringString) Keys() iters.Iter[string] {
+	return Map[string, string](c).Keys()
+}
+
+// Values method returns a lazy iters.Iter yielding the values of the map, in no particular order.
+func (c MapStringString) Values() iters.Iter[string] {
+	return Map[string, string](c).Values()
+}
+
+// All method returns an iter.Seq2 yielding the keys and values of the map, in no particular order.
+func (c MapStringInterface) All() iter.Seq2[string, interface{}] {
+	return Map[string, interface{}](c).All()
+}
+
+// Keys method returns a lazy iters.Iter yielding the keys of the map, in no particular order.
+func (c MapStringInterface) Keys() iters.Iter[string] {
+	return Map[string, interface{}](c).Keys()
+}
+
+// Values method returns a lazy iters.Iter yielding the values of the map, in no particular order.
+func (c MapStringInterface) Values() iters.Iter[interface{}] {
+	return Map[string, interface{}](c).Values()
+}
+
+// All method returns an iter.Seq2 yielding the keys and values of the map, in no particular order.
+func (c MapStringInt) All() iter.Seq2[string, int] {
+	return Map[string, int](c).All()
+}
+
+// Keys method returns a lazy iters.Iter yielding the keys of the map, in no particular order.
+func (c MapStringInt) Keys() iters.Iter[string] {
+	return Map[string, int](c).Keys()
+}
+
+// Values method returns a lazy iters.Iter yielding the values of the map, in no particular order.
+func (c MapStringInt) Values() iters.Iter[int] {
+	return Map[string, int](c).Values()
+}
+
+// All method returns an iter.Seq2 yielding the keys and values of the map, in no particular order.
+func (c MapStringFloat64) All() iter.Seq2[string, float64] {
+	return Map[string, float64](c).All()
+}
+
+// Keys method returns a lazy iters.Iter yielding the keys of the map, in no particular order.
+func (c MapStringFloat64) Keys() iters.Iter[string] {
+	return Map[string, float64](c).Keys()
+}
+
+// Values method returns a lazy iters.Iter yielding the values of the map, in no particular order.
+func (c MapStringFloat64) Values() iters.Iter[float64] {
+	return Map[string, float64](c).Values()
+}
+
+// All method returns an iter.Seq2 yielding the keys and values of the map, in no particular order.
+func (c MapStringFloat32) All() iter.Seq2[string, float32] {
+	return Map[string, float32](c).All()
+}
+
+// Keys method returns a lazy iters.Iter yielding the keys of the map, in no particular order.
+func (c MapStringFloat32) Keys() iters.Iter[string] {
+	return Map[string, float32](c).Keys()
+}
+
+// Values method returns a lazy iters.Iter yielding the values of the map, in no particular order.
+func (c MapStringFloat32) Values() iters.Iter[float32] {
+	return Map[string, float32](c).Values()
+}
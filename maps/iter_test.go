@@ -0,0 +1,37 @@
+//go:build go1.23
+
+package maps
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIter(t *testing.T) {
+	var test = MapStringInt{"foo": 1, "bar": 2, "baz": 3}
+
+	var all = make(map[string]int)
+	for k, v := range test.All() {
+		all[k] = v
+	}
+	assert.Equal(t, map[string]int{"foo": 1, "bar": 2, "baz": 3}, all, "All should yield every key and value")
+
+	keys := test.Keys().Collect()
+	sort.Strings(keys)
+	assert.Equal(t, []string{"bar", "baz", "foo"}, keys, "Keys should yield every key")
+
+	values := test.Values().Filter(func(v int) bool {
+		return v > 1
+	}).Collect()
+	sort.Ints(values)
+	assert.Equal(t, []int{2, 3}, values, "Values should be filtered lazily")
+
+	assert.Len(t, MapStringString{"a": "b"}.Values().Collect(), 1, "string map values")
+	assert.Len(t, MapStringInterface{"a": 1}.Keys().Collect(), 1, "interface map keys")
+	assert.Len(t, MapStringFloat64{"a": 1}.Values().Collect(), 1, "float64 map values")
+	assert.Len(t, MapStringFloat32{"a": 1}.Values().Collect(), 1, "float32 map values")
+	assert.Len(t, MapInterfaceInterface{1: 1}.Keys().Collect(), 1, "interface interface map keys")
+	assert.Len(t, Map[int, int]{1: 1, 2: 2}.Values().Take(1).Collect(), 1, "Take should stop the iteration")
+}
@@ -1,33 +1,337 @@
 package maps
 
-import "reflect"
+import (
+	"context"
+	"reflect"
 
-func intfSlice(slice interface{}) []interface{} {
-	s := reflect.ValueOf(slice)
-	if s.Kind() != reflect.Slice && s.Kind() != reflect.Array {
-		panic("intfSlice() given a non-slice type")
+	"github.com/francoispqt/lists"
+	"github.com/francoispqt/lists/internal/async"
+)
+
+// Map is a generic custom type for a map of any key and value types.
+type Map[K comparable, V any] map[K]V
+
+// Contains method determines whether a map includes a certain element, returning true or false as appropriate.
+// Slices and arrays are compared element by element.
+func (c Map[K, V]) Contains(s V) bool {
+	if comparableType(reflect.TypeOf((*V)(nil)).Elem()) {
+		for _, v := range c {
+			if interface{}(v) == interface{}(s) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, v := range c {
+		if equal(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// ForEach method executes a provided func once for each map element.
+func (c Map[K, V]) ForEach(cb func(K, V)) {
+	for k, v := range c {
+		cb(k, v)
 	}
+}
 
-	ret := make([]interface{}, s.Len())
+// Map method creates a new map with the results of calling a provided func on every element in the calling map.
+// Returns a map of the original type.
+// For asynchronicity, see MapAsync.
+func (c Map[K, V]) Map(cb func(K, V) V) Map[K, V] {
+	return MapValuesTo(c, cb)
+}
+
+// MapInterface method creates a new map with the results of calling a provided func on every element in the calling map.
+// Returns a map of interfaces.
+// For asynchronicity, see MapAsyncInterface.
+func (c Map[K, V]) MapInterface(cb func(K, V) interface{}) Map[K, interface{}] {
+	return MapValuesTo(c, cb)
+}
+
+// MapAsync method creates a new map with the results of calling a provided go routine on every element in the calling map.
+// The go routine must write a lists.Result to the chan, its Key being the key of the element.
+// An optional max concurrency can be passed as second argument, 0 means no limit.
+// Returns a map of the original type.
+// If you want to map to a map of different type, see MapAsyncInterface.
+func (c Map[K, V]) MapAsync(cb func(K, V, chan<- lists.Result[K, V]), maxConcurrency ...int) Map[K, V] {
+	return MapValuesAsyncTo(c, cb, maxConcurrency...)
+}
+
+// MapAsyncContext method is MapAsync with a context given to every go routine.
+// When the context is done, no more go routine is started and MapAsyncContext returns ctx.Err() with the results received so far.
+func (c Map[K, V]) MapAsyncContext(ctx context.Context, cb func(context.Context, K, V, chan<- lists.Result[K, V]), opts lists.Options) (Map[K, V], error) {
+	return MapValuesAsyncToContext(ctx, c, cb, opts)
+}
 
-	for i := 0; i < s.Len(); i++ {
-		ret[i] = s.Index(i).Interface()
+// MapAsyncErr method creates a new map with the values returned by calling a provided func as a go routine on every element in the calling map.
+// Errors returned by the func are collected as set by opts.ErrorMode and wrapped in a *lists.ElementError, elements which failed are left out of the map.
+// The context is given to every go routine, when it is done no more go routine is started.
+func (c Map[K, V]) MapAsyncErr(ctx context.Context, cb func(context.Context, K, V) (V, error), opts lists.Options) (Map[K, V], error) {
+	return MapValuesAsyncErrTo(ctx, c, cb, opts)
+}
+
+// MapAsyncStream method calls a provided go routine on every element in the calling map and returns a chan yielding the results as soon as they are written.
+// Maps have no order, opts.Ordered is ignored.
+// The chan is closed once every go routine is done, the error of the call (nil on success) is then written to the error chan.
+func (c Map[K, V]) MapAsyncStream(ctx context.Context, cb func(context.Context, K, V, chan<- lists.Result[K, V]), opts lists.Options) (<-chan lists.Result[K, V], <-chan error) {
+	return MapValuesAsyncStreamTo(ctx, c, cb, opts)
+}
+
+// MapAsyncRetry method is MapAsyncErr returning the lists.Outcome of every element, with its number of attempts.
+// Failed elements are retried as set by opts.Retry.
+func (c Map[K, V]) MapAsyncRetry(ctx context.Context, cb func(context.Context, K, V) (V, error), opts lists.Options) (map[K]lists.Outcome[V], error) {
+	return MapValuesAsyncRetryTo(ctx, c, cb, opts)
+}
+
+// MapParallel method creates a new map with the values returned by calling a provided func as a go routine on every element in the calling map.
+// No more than workers go routines run at the same time, 0 means no limit.
+// Returns a map of the original type.
+func (c Map[K, V]) MapParallel(cb func(K, V) V, workers int) Map[K, V] {
+	return MapValuesParallelTo(c, cb, workers)
+}
+
+// MapAsyncInterface method creates a new map with the results of calling a provided go routine on every element in the calling map.
+// The go routine must write a lists.Result to the chan, its Key being the key of the element.
+// Returns a map of interfaces.
+// If you know the result will be of original type, use MapAsync.
+func (c Map[K, V]) MapAsyncInterface(cb func(K, V, chan<- lists.Result[K, interface{}]), maxConcurrency ...int) Map[K, interface{}] {
+	return MapValuesAsyncTo(c, cb, maxConcurrency...)
+}
+
+// MapAsyncInterfaceContext method is MapAsyncInterface with a context given to every go routine.
+// When the context is done, no more go routine is started and MapAsyncInterfaceContext returns ctx.Err() with the results received so far.
+func (c Map[K, V]) MapAsyncInterfaceContext(ctx context.Context, cb func(context.Context, K, V, chan<- lists.Result[K, interface{}]), opts lists.Options) (Map[K, interface{}], error) {
+	return MapValuesAsyncToContext(ctx, c, cb, opts)
+}
+
+// Reduce method applies a func against an accumulator and each element in the map to reduce it to a single value of the original type.
+// To reduce to a value of any other type, see the Reduce func.
+// For asynchronicity, see ReduceAsync.
+func (c Map[K, V]) Reduce(cb func(K, V, V) V, agg V) V {
+	return Reduce(c, cb, agg)
+}
+
+// ReduceAsync method applies a go routine against an accumulator and each element in the map to reduce it to a single value of the original type.
+// You must read the aggregator from the lists.Aggregator.Agg channel before writing to the lists.Aggregator.Done channel.
+// To reduce to a value of any other type, see the ReduceAsync func.
+// For synchronicity, see Reduce.
+func (c Map[K, V]) ReduceAsync(cb func(K, V, *lists.Aggregator[V]), agg V) V {
+	return ReduceAsync(c, cb, agg)
+}
+
+// ReduceAsyncContext method is ReduceAsync with a context given to every go routine.
+// When the context is done, no more go routine is started and the current state of the accumulator is returned with ctx.Err().
+// Panics are recovered as set by opts.OnPanic, the state of the accumulator is left unchanged by a go routine which panicked.
+func (c Map[K, V]) ReduceAsyncContext(ctx context.Context, cb func(context.Context, K, V, *lists.Aggregator[V]), agg V, opts lists.Options) (V, error) {
+	return ReduceAsyncContext(ctx, c, cb, agg, opts)
+}
+
+// Indexes returns a slice including the indexes (keys) of the map
+func (c Map[K, V]) Indexes() []K {
+	var indexes = make([]K, 0, len(c))
+	for k := range c {
+		indexes = append(indexes, k)
 	}
+	return indexes
+}
 
+// Filter method creates a new map with all elements that pass the test implemented by the provided function.
+func (c Map[K, V]) Filter(cb func(k K, v V) bool) Map[K, V] {
+	var ret = make(map[K]V, 0)
+	for k, v := range c {
+		if cb(k, v) {
+			ret[k] = v
+		}
+	}
 	return ret
 }
 
-func compare(X, Y interface{}) bool {
-	xx := intfSlice(X)
-	yy := intfSlice(Y)
-	for k, v := range xx {
-		if len(yy)-1 >= k {
-			vv := yy[k]
-			if vv == v {
-				continue
+// Cast explicitly cast the Map to a map[K]V type
+func (c Map[K, V]) Cast() map[K]V {
+	return c
+}
+
+// MapValuesTo func creates a new map with the results of calling a provided func on every element in the calling map.
+// Returns a map of the type returned by the func, indexed by the original keys.
+// For asynchronicity, see MapValuesAsyncTo.
+func MapValuesTo[K comparable, V any, U any](c map[K]V, cb func(K, V) U) map[K]U {
+	var ret = make(map[K]U, len(c))
+	for k, v := range c {
+		ret[k] = cb(k, v)
+	}
+	return ret
+}
+
+// MapValuesAsyncTo func creates a new map with the results of calling a provided go routine on every element in the calling map.
+// The go routine must write a lists.Result to the chan, its Key being the key of the element.
+// An optional max concurrency can be passed as third argument, 0 means no limit.
+// Returns a map of the type written to the chan, indexed by the original keys.
+// For synchronicity, see MapValuesTo.
+func MapValuesAsyncTo[K comparable, V any, U any](c map[K]V, cb func(K, V, chan<- lists.Result[K, U]), maxConcurrency ...int) map[K]U {
+	var ret = make(map[K]U, len(c))
+	async.Map(async.Conc(maxConcurrency), resultJob(c, func(_ context.Context, k K, v V, mapChan chan<- lists.Result[K, U]) {
+		cb(k, v, mapChan)
+	}, ret))
+	return ret
+}
+
+// MapValuesAsyncToContext func is MapValuesAsyncTo with a context given to every go routine.
+// When the context is done, no more go routine is started and MapValuesAsyncToContext returns ctx.Err() with the results received so far.
+func MapValuesAsyncToContext[K comparable, V any, U any](ctx context.Context, c map[K]V, cb func(context.Context, K, V, chan<- lists.Result[K, U]), opts lists.Options) (map[K]U, error) {
+	var ret = make(map[K]U, len(c))
+	err := async.MapContext(ctx, opts, resultJob(c, cb, ret))
+	return ret, err
+}
+
+// MapValuesAsyncStreamTo func calls a provided go routine on every element in the calling map and returns a chan yielding the lists.Result written by each go routine as soon as it is written.
+// Maps have no order, opts.Ordered is ignored.
+// The chan is closed once every go routine is done, the error of the call (nil on success) is then written to the error chan.
+// When the context is done, no more go routine is started and results not yet read are dropped.
+// For a map of the results, see MapValuesAsyncToContext.
+func MapValuesAsyncStreamTo[K comparable, V any, U any](ctx context.Context, c map[K]V, cb func(context.Context, K, V, chan<- lists.Result[K, U]), opts lists.Options) (<-chan lists.Result[K, U], <-chan error) {
+	opts.Ordered = false
+	return async.Stream(ctx, opts, resultJob(c, cb, nil))
+}
+
+// resultJob returns the job of the lists.Result protocol, storing the values to ret.
+func resultJob[K comparable, V any, U any](c map[K]V, cb func(context.Context, K, V, chan<- lists.Result[K, U]), ret map[K]U) async.Job[lists.Result[K, U]] {
+	var keys, values = entries(c)
+	var index = positions(keys)
+	return async.Job[lists.Result[K, U]]{
+		N: len(keys),
+		Key: func(i int) interface{} {
+			return keys[i]
+		},
+		Launch: func(ctx context.Context, i int, mapChan chan lists.Result[K, U]) {
+			cb(ctx, keys[i], values[i], mapChan)
+		},
+		Index: func(r lists.Result[K, U]) (int, error) {
+			return index(r.Key)
+		},
+		Store: func(_ int, r lists.Result[K, U]) {
+			ret[r.Key] = r.Value
+		},
+		Fallback: func(i int, v interface{}) (lists.Result[K, U], bool) {
+			u, ok := async.As[U](v)
+			return lists.Result[K, U]{Key: keys[i], Value: u}, ok
+		},
+	}
+}
+
+// MapValuesAsyncErrTo func creates a new map with the values returned by calling a provided func as a go routine on every element in the calling map.
+// Errors returned by the func are collected as set by opts.ErrorMode and wrapped in a *lists.ElementError, elements which failed are left out of the map.
+// The context is given to every go routine, when it is done no more go routine is started.
+func MapValuesAsyncErrTo[K comparable, V any, U any](ctx context.Context, c map[K]V, cb func(context.Context, K, V) (U, error), opts lists.Options) (map[K]U, error) {
+	var ret = make(map[K]U, len(c))
+	var keys, values = entries(c)
+	err := async.MapErr(
+		ctx,
+		len(keys),
+		opts,
+		func(i int) interface{} {
+			return keys[i]
+		},
+		func(ctx context.Context, i int) (U, error) {
+			v, err := cb(ctx, keys[i], values[i])
+			if err != nil {
+				return v, &lists.ElementError{Key: keys[i], Err: err}
 			}
-		}
-		return false
+			return v, nil
+		},
+		func(i int, v U) {
+			ret[keys[i]] = v
+		},
+	)
+	return ret, err
+}
+
+// MapValuesAsyncRetryTo func is MapValuesAsyncErrTo returning the lists.Outcome of every element: its value, the error of its last attempt and its number of attempts.
+// Failed elements are retried as set by opts.Retry. Elements not done when the map stops are left out of the map.
+func MapValuesAsyncRetryTo[K comparable, V any, U any](ctx context.Context, c map[K]V, cb func(context.Context, K, V) (U, error), opts lists.Options) (map[K]lists.Outcome[U], error) {
+	var ret = make(map[K]lists.Outcome[U], len(c))
+	var keys, values = entries(c)
+	err := async.MapOutcomes(
+		ctx,
+		len(keys),
+		opts,
+		func(i int) interface{} {
+			return keys[i]
+		},
+		func(ctx context.Context, i int) (U, error) {
+			v, err := cb(ctx, keys[i], values[i])
+			if err != nil {
+				return v, &lists.ElementError{Key: keys[i], Err: err}
+			}
+			return v, nil
+		},
+		func(i int, o lists.Outcome[U]) {
+			ret[keys[i]] = o
+		},
+	)
+	return ret, err
+}
+
+// MapValuesParallelTo func creates a new map with the values returned by calling a provided func as a go routine on every element in the calling map.
+// No more than workers go routines run at the same time, 0 means no limit.
+// Returns a map of the type returned by the func, indexed by the original keys.
+func MapValuesParallelTo[K comparable, V any, U any](c map[K]V, cb func(K, V) U, workers int) map[K]U {
+	var ret = make(map[K]U, len(c))
+	var keys, values = entries(c)
+	async.Parallel(
+		len(keys),
+		workers,
+		func(i int) U {
+			return cb(keys[i], values[i])
+		},
+		func(i int, v U) {
+			ret[keys[i]] = v
+		},
+	)
+	return ret
+}
+
+// Reduce func applies a func against an accumulator and each element in the map to reduce it to a single value of any type.
+// For asynchronicity, see ReduceAsync.
+func Reduce[K comparable, V any, A any](c map[K]V, cb func(K, V, A) A, agg A) A {
+	for k, v := range c {
+		agg = cb(k, v, agg)
 	}
-	return true
+	return agg
+}
+
+// ReduceAsync func applies a go routine against an accumulator and each element in the map to reduce it to a single value of any type.
+// You must read the aggregator from the lists.Aggregator.Agg channel before writing to the lists.Aggregator.Done channel.
+// Go routines are run in series waiting for the previous go routine writing to the lists.Aggregator.Done channel.
+// For synchronicity, see Reduce.
+func ReduceAsync[K comparable, V any, A any](c map[K]V, cb func(K, V, *lists.Aggregator[A]), agg A) A {
+	var keys, values = entries(c)
+	return async.Reduce(
+		len(keys),
+		func(i int, agg *lists.Aggregator[A]) {
+			cb(keys[i], values[i], agg)
+		},
+		agg,
+	)
+}
+
+// ReduceAsyncContext func is ReduceAsync with a context given to every go routine.
+// When the context is done, no more go routine is started and the current state of the accumulator is returned with ctx.Err().
+// Panics are recovered as set by opts.OnPanic, the state of the accumulator is left unchanged by a go routine which panicked.
+func ReduceAsyncContext[K comparable, V any, A any](ctx context.Context, c map[K]V, cb func(context.Context, K, V, *lists.Aggregator[A]), agg A, opts lists.Options) (A, error) {
+	var keys, values = entries(c)
+	return async.ReduceContext(
+		ctx,
+		len(keys),
+		opts,
+		func(i int) interface{} {
+			return keys[i]
+		},
+		func(ctx context.Context, i int, agg *lists.Aggregator[A]) {
+			cb(ctx, keys[i], values[i], agg)
+		},
+		agg,
+	)
 }
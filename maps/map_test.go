@@ -1,5 +1,358 @@
 package maps
 
-type TesStruct struct {
-	Foo string
+import (
+	"context"
+	"errors"
+	"math"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/francoispqt/lists"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMap(t *testing.T) {
+	var test = Map[int, string]{1: "hello", 2: "foo"}
+
+	assert.ElementsMatch(t, []int{1, 2}, test.Indexes(), "indexes should contain the keys")
+	assert.True(t, test.Contains("hello"), "test should contain hello")
+	assert.False(t, test.Contains("world"), "test should not contain world")
+
+	forEachT := 0
+	test.ForEach(func(k int, v string) {
+		forEachT += k
+	})
+	assert.Equal(t, 3, forEachT, "foreach should have updated forEachT to be 3")
+
+	mapped := test.Map(func(k int, v string) string {
+		return v + " !"
+	})
+	assert.Equal(t, Map[int, string]{1: "hello !", 2: "foo !"}, mapped, "map should update every element")
+
+	mappedIntf := test.MapInterface(func(k int, v string) interface{} {
+		return len(v)
+	})
+	assert.Equal(t, Map[int, interface{}]{1: 5, 2: 3}, mappedIntf, "map interface should convert every element")
+
+	longest := test.Reduce(func(k int, v string, agg string) string {
+		if len(v) > len(agg) {
+			return v
+		}
+		return agg
+	}, "")
+	assert.Equal(t, "hello", longest, "longest should be hello")
+
+	total := Reduce(test, func(k int, v string, agg int) int {
+		return agg + k
+	}, 0)
+	assert.Equal(t, 3, total, "reduce func should reduce to an int")
+
+	filtered := test.Filter(func(k int, v string) bool {
+		return k > 1
+	})
+	assert.Equal(t, Map[int, string]{2: "foo"}, filtered, "filter should remove key 1")
+	assert.IsType(t, map[int]string{}, filtered.Cast(), "cast should give original type")
+}
+
+func TestMapContains(t *testing.T) {
+	var test = Map[string, TesStruct]{"a": {Foo: "bar"}}
+	assert.True(t, test.Contains(TesStruct{Foo: "bar"}), "test should contain TesStruct{Foo: \"bar\"}")
+	assert.False(t, test.Contains(TesStruct{Foo: "baz"}), "test should not contain TesStruct{Foo: \"baz\"}")
+
+	var testSlices = Map[string, []int]{"a": {1, 2}}
+	assert.True(t, testSlices.Contains([]int{1, 2}), "test should contain []int{1, 2}")
+	assert.False(t, testSlices.Contains([]int{2, 1}), "test should not contain []int{2, 1}")
+
+	var testMaps = Map[string, map[string]int]{"a": {"b": 1}}
+	assert.True(t, testMaps.Contains(map[string]int{"b": 1}), "test should contain map[b:1]")
+	assert.False(t, testMaps.Contains(nil), "test should not contain nil")
+
+	var testArrays = Map[string, [2]int]{"a": {1, 2}}
+	assert.True(t, testArrays.Contains([2]int{1, 2}), "test should contain [2]int{1, 2}")
+	assert.False(t, testArrays.Contains([2]int{2, 1}), "test should not contain [2]int{2, 1}")
+
+	var testIntf = Map[string, interface{}]{"a": []int{1}, "b": 2}
+	assert.True(t, testIntf.Contains(2), "test should contain 2")
+	assert.True(t, testIntf.Contains([]int{1}), "test should contain []int{1}")
+	assert.False(t, testIntf.Contains(map[string]int{}), "test should not contain map[]")
+
+	type withIntf struct {
+		X interface{}
+	}
+	var testStructs = Map[string, withIntf]{"a": {X: []int{1}}}
+	assert.True(t, testStructs.Contains(withIntf{X: []int{1}}), "structs holding slices should be deeply compared")
+	assert.False(t, testStructs.Contains(withIntf{X: 1}), "test should not contain {X: 1}")
+	var testNested = Map[string, [1]withIntf]{"a": {{X: []int{1}}}}
+	assert.True(t, testNested.Contains([1]withIntf{{X: []int{1}}}), "arrays of structs holding slices should be deeply compared")
+	assert.False(t, testNested.Contains([1]withIntf{{X: []int{2}}}), "test should not contain [{X: [2]}]")
+}
+
+func TestMapContainsComparable(t *testing.T) {
+	var test = MapStringFloat64{"a": 1.5, "b": math.NaN()}
+	assert.True(t, test.Contains(1.5), "test should contain 1.5")
+	assert.False(t, test.Contains(math.NaN()), "test should not contain NaN, as NaN != NaN")
+	assert.True(t, MapStringString{"a": "b"}.Contains("b"), "test should contain b")
+}
+
+func TestMapAsync(t *testing.T) {
+	var test = Map[string, int]{"a": 1, "b": 2, "c": 3}
+
+	for _, maxConc := range []int{0, 1, 2, 10} {
+		ret := test.MapAsync(func(k string, v int, done chan<- lists.Result[string, int]) {
+			if k == "a" {
+				time.Sleep(10 * time.Millisecond)
+			}
+			done <- lists.Result[string, int]{Key: k, Value: v * 2}
+		}, maxConc)
+		assert.Equal(t, Map[string, int]{"a": 2, "b": 4, "c": 6}, ret, "map async should map back to original key")
+
+		retIntf := test.MapAsyncInterface(func(k string, v int, done chan<- lists.Result[string, interface{}]) {
+			go func() {
+				done <- lists.Result[string, interface{}]{Key: k, Value: strconv.Itoa(v)}
+			}()
+		}, maxConc)
+		assert.Equal(t, Map[string, interface{}]{"a": "1", "b": "2", "c": "3"}, retIntf, "map async interface should map back to original key")
+	}
+
+	sum := test.ReduceAsync(func(k string, v int, agg *lists.Aggregator[int]) {
+		agg.Done <- <-agg.Agg + v
+	}, 0)
+	assert.Equal(t, 6, sum, "sum should be 6")
+
+	keys := ReduceAsync(test, func(k string, v int, agg *lists.Aggregator[[]string]) {
+		agg.Done <- append(<-agg.Agg, k)
+	}, nil)
+	assert.ElementsMatch(t, []string{"a", "b", "c"}, keys, "reduce async func should collect the keys")
+}
+
+func TestMapValuesTo(t *testing.T) {
+	var test = MapStringString{"a": "1", "b": "22"}
+
+	lens := MapValuesTo(test, func(k string, v string) int {
+		return len(v)
+	})
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, lens, "map values to should give the len of every element")
+
+	for _, maxConc := range []int{0, 1} {
+		ret := MapValuesAsyncTo(test, func(k string, v string, done chan<- lists.Result[string, []byte]) {
+			go func() {
+				done <- lists.Result[string, []byte]{Key: k, Value: []byte(v)}
+			}()
+		}, maxConc)
+		assert.Equal(t, map[string][]byte{"a": []byte("1"), "b": []byte("22")}, ret, "map values async to should map back to original key")
+	}
+}
+
+func TestMapAsyncContext(t *testing.T) {
+	var test = Map[string, int]{"a": 1, "b": 2}
+
+	ret, err := test.MapAsyncContext(context.Background(), func(ctx context.Context, k string, v int, done chan<- lists.Result[string, int]) {
+		done <- lists.Result[string, int]{Key: k, Value: v * 10}
+	}, lists.Options{MaxConcurrency: 1})
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, Map[string, int]{"a": 10, "b": 20}, ret, "map async context should map back to original key")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = MapStringString{"a": "b"}.MapAsyncContext(ctx, func(ctx context.Context, k string, v string, done chan [2]string) {
+		done <- [2]string{k, v}
+	}, lists.Options{})
+	assert.Equal(t, context.Canceled, err, "err should be context.Canceled")
+}
+
+func TestMapNaNKey(t *testing.T) {
+	// a NaN key is not equal to itself, its value cannot be looked up by key
+	var test = Map[float64, int]{math.NaN(): 7}
+	var seen []int
+	_, err := test.MapAsyncErr(context.Background(), func(_ context.Context, k float64, v int) (int, error) {
+		seen = append(seen, v)
+		return v, nil
+	}, lists.Options{})
+	assert.Nil(t, err, "err should be nil")
+	test.MapParallel(func(k float64, v int) int {
+		seen = append(seen, v)
+		return v
+	}, 1)
+	sum := test.ReduceAsync(func(k float64, v int, agg *lists.Aggregator[int]) {
+		agg.Done <- <-agg.Agg + v
+	}, 0)
+	assert.Equal(t, []int{7, 7}, seen, "callbacks should be given the value of the NaN key")
+	assert.Equal(t, 7, sum, "reduce should be given the value of the NaN key")
+
+	lists.Debug = true
+	defer func() {
+		lists.Debug = false
+	}()
+	mapped := test.MapAsync(func(k float64, v int, done chan<- lists.Result[float64, int]) {
+		done <- lists.Result[float64, int]{Key: k, Value: v * 2}
+	})
+	assert.Len(t, mapped, 1, "the NaN key should be mapped")
+	for k, v := range mapped {
+		assert.True(t, math.IsNaN(k), "the key should be NaN")
+		assert.Equal(t, 14, v, "the value of the NaN key should be mapped")
+	}
+	legacy := MapInterfaceInterface{math.NaN(): 1, math.NaN(): 2, "a": 3}.MapAsync(func(k interface{}, v interface{}, done chan [2]interface{}) {
+		done <- [2]interface{}{k, v.(int) * 2}
+	})
+	assert.Len(t, legacy, 3, "every NaN key should be mapped")
+	assert.Equal(t, 6, legacy["a"], "other keys should be mapped")
+}
+
+func TestMapAsyncErr(t *testing.T) {
+	var test = MapStringString{"a": "1", "b": "x"}
+
+	ret, err := MapValuesAsyncErrTo(context.Background(), test, func(ctx context.Context, k string, v string) (int, error) {
+		return strconv.Atoi(v)
+	}, lists.Options{ErrorMode: lists.JoinErrors})
+	assert.Equal(t, map[string]int{"a": 1}, ret, "elements which failed should be left out of the map")
+
+	var elemErr *lists.ElementError
+	assert.True(t, errors.As(err, &elemErr), "err should be a *lists.ElementError")
+	assert.Equal(t, "b", elemErr.Key, "key of the error should be the key of the element")
+
+	mapped, err := test.MapAsyncErr(context.Background(), func(ctx context.Context, k string, v string) (string, error) {
+		return k + v, nil
+	}, lists.Options{MaxConcurrency: 1})
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, MapStringString{"a": "a1", "b": "bx"}, mapped, "map async err should map back to original key")
+}
+
+func TestMapReduceAsyncContext(t *testing.T) {
+	var test = Map[string, int]{"a": 1, "b": 2}
+
+	sum, err := test.ReduceAsyncContext(context.Background(), func(ctx context.Context, k string, v int, agg *lists.Aggregator[int]) {
+		state := <-agg.Agg
+		if k == "b" {
+			panic("boom")
+		}
+		agg.Done <- state + v
+	}, 0, lists.Options{OnPanic: lists.PanicContinue})
+
+	var elemErr *lists.ElementError
+	assert.True(t, errors.As(err, &elemErr), "err should be a *lists.ElementError")
+	assert.Equal(t, "b", elemErr.Key, "key should be the key of the element which panicked")
+	assert.Equal(t, 1, sum, "sum should skip the element which panicked")
+}
+
+func TestMapParallel(t *testing.T) {
+	var test = Map[string, int]{"a": 1, "b": 2, "c": 3}
+
+	ret := test.MapParallel(func(k string, v int) int {
+		return v * v
+	}, 2)
+	assert.Equal(t, Map[string, int]{"a": 1, "b": 4, "c": 9}, ret, "map parallel should map back to original key")
+
+	strs := MapValuesParallelTo(test, func(k string, v int) string {
+		return k + strconv.Itoa(v)
+	}, 0)
+	assert.Equal(t, map[string]string{"a": "a1", "b": "b2", "c": "c3"}, strs, "map values parallel to should convert every element")
+
+	legacy := MapStringString{"a": "b"}.MapParallel(func(k string, v string) string {
+		return k + v
+	}, 1)
+	assert.Equal(t, MapStringString{"a": "ab"}, legacy, "legacy map parallel should map back to original key")
+}
+
+func TestMapAsyncStream(t *testing.T) {
+	var test = Map[string, int]{"a": 3, "b": 1, "c": 2}
+	results, errc := test.MapAsyncStream(context.Background(), func(_ context.Context, k string, v int, done chan<- lists.Result[string, int]) {
+		time.Sleep(time.Duration(v) * 20 * time.Millisecond)
+		done <- lists.Result[string, int]{Key: k, Value: v * 2}
+	}, lists.Options{Ordered: true})
+	var got []string
+	for r := range results {
+		got = append(got, r.Key)
+	}
+	assert.Nil(t, <-errc, "err should be nil")
+	assert.Equal(t, []string{"b", "c", "a"}, got, "results should be yielded as they complete")
+
+	legacy, errc := MapStringString{"a": "b"}.MapAsyncStream(context.Background(), func(_ context.Context, k string, v string, done chan<- lists.Result[string, string]) {
+		done <- lists.Result[string, string]{Key: k, Value: k + v}
+	}, lists.Options{})
+	assert.Equal(t, lists.Result[string, string]{Key: "a", Value: "ab"}, <-legacy, "legacy types should stream their results")
+	_, open := <-legacy
+	assert.False(t, open, "the chan should be closed once every go routine is done")
+	assert.Nil(t, <-errc, "err should be nil")
+}
+
+func TestMapAsyncDebug(t *testing.T) {
+	var elemErr *lists.ElementError
+
+	_, err := MapStringString{"a": "a"}.MapAsyncContext(context.Background(), func(_ context.Context, k string, v string, done chan [2]string) {
+		done <- [2]string{"b", v}
+	}, lists.Options{})
+	assert.True(t, errors.As(err, &elemErr), "err should be a *lists.ElementError")
+	assert.Equal(t, "b", elemErr.Key, "key should be the one written to the chan")
+	assert.Contains(t, err.Error(), "key written to the chan is not in the map")
+
+	lists.DebugGrace = 50 * time.Millisecond
+	defer func() {
+		lists.DebugGrace = 0
+	}()
+	_, err = MapStringInt{"a": 1, "b": 2}.MapAsyncContext(context.Background(), func(_ context.Context, k string, v int, done chan [2]interface{}) {
+		if k == "a" {
+			done <- [2]interface{}{k, v}
+		}
+	}, lists.Options{Debug: true})
+	assert.True(t, errors.As(err, &elemErr), "err should be a *lists.ElementError")
+	assert.Equal(t, "b", elemErr.Key, "key should be the element which did not write to the chan")
+}
+
+func TestMapAsyncRateLimit(t *testing.T) {
+	test := MapStringInt{"a": 1, "b": 2, "c": 3, "d": 4}
+	start := time.Now()
+	result, err := test.MapAsyncInterfaceContext(context.Background(), func(_ context.Context, k string, v int, done chan [2]interface{}) {
+		done <- [2]interface{}{k, strconv.Itoa(v)}
+	}, lists.Options{RateLimit: lists.NewLimiter(50, 1)})
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, MapStringInterface{"a": "1", "b": "2", "c": "3", "d": "4"}, result, "values should be mapped")
+	assert.True(t, time.Since(start) >= 55*time.Millisecond, "the rate should be limited to 50 per second")
+}
+
+func TestMapAsyncRetry(t *testing.T) {
+	errTransient := errors.New("transient")
+	var failed int32
+	outcomes, err := Map[string, int]{"a": 1, "b": 2}.MapAsyncRetry(context.Background(), func(_ context.Context, k string, v int) (int, error) {
+		if k == "b" && atomic.CompareAndSwapInt32(&failed, 0, 1) {
+			return 0, errTransient
+		}
+		return v * 2, nil
+	}, lists.Options{Retry: &lists.RetryPolicy{MaxAttempts: 2}})
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, map[string]lists.Outcome[int]{
+		"a": {Value: 2, Attempts: 1},
+		"b": {Value: 4, Attempts: 2},
+	}, outcomes, "outcomes should hold values and attempts")
+}
+
+func TestMapAsyncTimeout(t *testing.T) {
+	result, err := MapStringString{"fast": "a", "slow": "b"}.MapAsyncContext(context.Background(), func(ctx context.Context, k string, v string, done chan [2]string) {
+		if k == "slow" {
+			<-ctx.Done()
+		}
+		done <- [2]string{k, v + "!"}
+	}, lists.Options{ElementTimeout: 20 * time.Millisecond})
+	assert.True(t, errors.Is(err, lists.ErrElementTimeout), "err should be lists.ErrElementTimeout")
+	assert.Equal(t, MapStringString{"fast": "a!"}, result, "slow element should be left out of the map")
+
+	values, err := Map[string, int]{"fast": 1, "slow": 2}.MapAsyncErr(context.Background(), func(ctx context.Context, k string, v int) (int, error) {
+		if k == "slow" {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}
+		return v, nil
+	}, lists.Options{ElementTimeout: 20 * time.Millisecond, TimeoutFallback: -1})
+	assert.Nil(t, err, "err should be nil with a fallback")
+	assert.Equal(t, Map[string, int]{"fast": 1, "slow": -1}, values, "slow element should be set to the fallback")
+}
+
+func TestMapAsyncProgress(t *testing.T) {
+	progress := lists.NewProgress()
+	_, err := MapStringInt{"a": 1, "b": 2, "c": 3}.MapAsyncContext(context.Background(), func(_ context.Context, k string, v int, done chan [2]interface{}) {
+		done <- [2]interface{}{k, v}
+	}, lists.Options{Observer: progress})
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, 3, progress.Snapshot().Completed, "every element should be completed")
 }
@@ -0,0 +1,174 @@
+package maps
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/francoispqt/lists"
+	"github.com/francoispqt/lists/internal/async"
+)
+
+func intfSlice(slice interface{}) []interface{} {
+	s := reflect.ValueOf(slice)
+	if s.Kind() != reflect.Slice && s.Kind() != reflect.Array {
+		panic("intfSlice() given a non-slice type")
+	}
+
+	ret := make([]interface{}, s.Len())
+
+	for i := 0; i < s.Len(); i++ {
+		ret[i] = s.Index(i).Interface()
+	}
+
+	return ret
+}
+
+func compare(X, Y interface{}) bool {
+	xx := intfSlice(X)
+	yy := intfSlice(Y)
+	for k, v := range xx {
+		if len(yy)-1 >= k {
+			vv := yy[k]
+			if equal(v, vv) {
+				continue
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// entries returns the keys and the values of c, the value of keys[i] being values[i].
+// Values are read along with their keys, not looked up by key, as a NaN key is not equal to itself.
+func entries[K comparable, V any](c map[K]V) ([]K, []V) {
+	var keys = make([]K, 0, len(c))
+	var values = make([]V, 0, len(c))
+	for k, v := range c {
+		keys = append(keys, k)
+		values = append(values, v)
+	}
+	return keys, values
+}
+
+// contains reports whether c includes s, values being compared with ==.
+func contains[K comparable, V comparable](c map[K]V, s V) bool {
+	for _, v := range c {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// comparableType reports whether the values of t can be compared with == without reflect:
+// comparable types which are not arrays, compared element by element,
+// and from which no interface can be reached, its dynamic values could be slices or maps.
+func comparableType(t reflect.Type) bool {
+	return t.Kind() != reflect.Array && noInterface(t)
+}
+
+// noInterface reports whether t is comparable and no interface can be reached from it, through its fields or elements.
+func noInterface(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Interface:
+		return false
+	case reflect.Array:
+		return noInterface(t.Elem())
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if !noInterface(t.Field(i).Type) {
+				return false
+			}
+		}
+	}
+	return t.Comparable()
+}
+
+// equal reports whether x and y are equal.
+// Slices and arrays are compared element by element, values of other types which are not comparable
+// or from which an interface can be reached are deeply compared.
+func equal(x, y interface{}) bool {
+	xVal := reflect.ValueOf(x)
+	switch xVal.Kind() {
+	case reflect.Slice, reflect.Array:
+		return reflect.ValueOf(y).Kind() == xVal.Kind() && compare(x, y)
+	case reflect.Invalid:
+		return y == nil
+	}
+	if !comparableType(xVal.Type()) {
+		return reflect.DeepEqual(x, y)
+	}
+	return x == y
+}
+
+// mapAsyncIntf runs the async map protocol of the legacy types,
+// where go routines write a [2]interface{} to the chan, the first element being the key and the second one a U.
+func mapAsyncIntf[K comparable, V any, U any](c map[K]V, cb func(K, V, chan [2]interface{}), maxConcurrency []int, store func(K, U)) {
+	async.Map(async.Conc(maxConcurrency), intfJob(c, func(_ context.Context, k K, v V, mapChan chan [2]interface{}) {
+		cb(k, v, mapChan)
+	}, store))
+}
+
+// mapAsyncIntfContext is mapAsyncIntf with a context given to every go routine.
+func mapAsyncIntfContext[K comparable, V any, U any](ctx context.Context, c map[K]V, cb func(context.Context, K, V, chan [2]interface{}), opts lists.Options, store func(K, U)) error {
+	return async.MapContext(ctx, opts, intfJob(c, cb, store))
+}
+
+// intfJob returns the job of the [2]interface{} protocol, giving the values to store.
+func intfJob[K comparable, V any, U any](c map[K]V, cb func(context.Context, K, V, chan [2]interface{}), store func(K, U)) async.Job[[2]interface{}] {
+	var keys, values = entries(c)
+	var index = positions(keys)
+	return async.Job[[2]interface{}]{
+		N: len(keys),
+		Key: func(i int) interface{} {
+			return keys[i]
+		},
+		Launch: func(ctx context.Context, i int, mapChan chan [2]interface{}) {
+			cb(ctx, keys[i], values[i], mapChan)
+		},
+		Index: func(intf [2]interface{}) (int, error) {
+			k, _, err := async.Intf[K, U](intf)
+			if err != nil {
+				return 0, err
+			}
+			return index(k)
+		},
+		Store: func(i int, intf [2]interface{}) {
+			v, _ := async.As[U](intf[1])
+			store(keys[i], v)
+		},
+		Fallback: func(i int, v interface{}) ([2]interface{}, bool) {
+			_, ok := async.As[U](v)
+			return [2]interface{}{keys[i], v}, ok
+		},
+	}
+}
+
+// positions returns a func giving the position of a key in keys, or a *lists.ProtocolError if it is not one of them.
+// Keys not equal to themselves, such as NaNs, cannot be looked up: they are given the positions of such keys in turn.
+func positions[K comparable](keys []K) func(K) (int, error) {
+	var pos = make(map[K]int, len(keys))
+	var unequal []int
+	for i, k := range keys {
+		if k != k {
+			unequal = append(unequal, i)
+			continue
+		}
+		pos[k] = i
+	}
+	var next int
+	return func(k K) (int, error) {
+		if k != k && len(unequal) > 0 {
+			i := unequal[next]
+			if next < len(unequal)-1 {
+				next++
+			}
+			return i, nil
+		}
+		i, ok := pos[k]
+		if !ok {
+			return 0, async.ProtocolError(k, "key written to the chan is not in the map")
+		}
+		return i, nil
+	}
+}
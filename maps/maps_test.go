@@ -0,0 +1,5 @@
+package maps
+
+type TesStruct struct {
+	Foo string
+}
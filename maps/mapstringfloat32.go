@@ -1,261 +1,149 @@
 package maps
 
-import "github.com/francoispqt/lists"
+import (
+	"context"
 
+	"github.com/francoispqt/lists"
+)
+
+// MapStringFloat32 is a custom type for a map[string]float32.
+// It is kept for compatibility, its methods delegate to Map[string, float32].
 type MapStringFloat32 map[string]float32
 
-// Contains method determines whether a slice includes a certain element, returning true or false as appropriate.
+// Contains method determines whether a map includes a certain element, returning true or false as appropriate.
 func (c MapStringFloat32) Contains(s float32) bool {
-	for _, v := range c {
-		if v == s {
-			return true
-		}
-	}
-	return false
+	return contains(c, s)
 }
 
-// ForEach method executes a provided func once for each slice element.
+// ForEach method executes a provided func once for each map element.
 func (c MapStringFloat32) ForEach(cb func(string, float32)) {
-	for k, v := range c {
-		cb(k, v)
-	}
+	Map[string, float32](c).ForEach(cb)
 }
 
-// MapInterface method creates a new slice with the results of calling a provided func on every element in the calling array.
-// Returns a slice of string (original type).
+// Map method creates a new map with the results of calling a provided func on every element in the calling map.
+// Returns a MapStringFloat32 (original type).
 // For asynchronicity, see MapAsync.
 func (c MapStringFloat32) Map(cb func(string, float32) float32) MapStringFloat32 {
-	var ret = make(map[string]float32, len(c))
-	for k, v := range c {
-		ret[k] = cb(k, v)
-	}
-	return ret
+	return MapStringFloat32(Map[string, float32](c).Map(cb))
 }
 
-// MapInterface method creates a new slice with the results of calling a provided func on every element in the calling array.
-// Returns a slice of interfaces.
+// MapInterface method creates a new map with the results of calling a provided func on every element in the calling map.
+// Returns a MapStringInterface.
 // For asynchronicity, see MapAsyncInterface.
 func (c MapStringFloat32) MapInterface(cb func(string, float32) interface{}) MapStringInterface {
-	var ret = make(map[string]interface{}, len(c))
-	for k, v := range c {
-		ret[k] = cb(k, v)
-	}
-	return ret
+	return MapStringInterface(Map[string, float32](c).MapInterface(cb))
 }
 
-// MapAsync method creates a new slice with the results of calling a provided go routine on every element in the calling array.
-// Runs asynchronously and needs gives a chan []interface{} to return results.
-// To keep initial order, the first elemt of th []interface{} written to the chan must be the key. The second element muse be a string.
-// Returns a StringSlice (original type).
-// If you want to map to a slice of different type, see MapAsyncInterface.
+// MapAsync method creates a new map with the results of calling a provided go routine on every element in the calling map.
+// Runs asynchronously and gives a chan [2]interface{} to return results.
+// The first element of the [2]interface{} written to the chan must be the key. The second element must be a float32.
+// Returns a MapStringFloat32 (original type).
+// For a typed chan, see Map.MapAsync.
 func (c MapStringFloat32) MapAsync(cb func(string, float32, chan [2]interface{}), maxConcurrency ...int) MapStringFloat32 {
-	var maxConc = lists.DEFAULT_CONC
-	if len(maxConcurrency) == 1 {
-		maxConc = maxConcurrency[0]
-	}
-
-	mapChan := make(chan [2]interface{}, len(c))
-	ret := make(map[string]float32, len(c))
-
-	// if maxConc is higher than 0 length of chan doing is lower than maxConc && counter is lower than lenght of slice continue
-	// else start reading from the chan to decrease concurrency
-	if maxConc > 0 {
-
-		sent := 0
-		received := 0
-		doing := make(chan struct{}, maxConc)
-		indexes := c.Indexes()
-
-		for {
-
-			var k string
-			var v float32
-			if len(indexes) > sent {
-				k = indexes[sent]
-				v = c[k]
-			}
-
-			if len(doing) < maxConc && sent < len(c) {
-				go cb(k, v, mapChan)
-				sent++
-				if maxConc > 0 {
-					doing <- struct{}{}
-				}
-			} else {
-
-				// start reading my chan
-				intf := <-mapChan
-				received++
-
-				ret[intf[0].(string)] = intf[1].(float32)
-
-				// reading doing to continue the loop
-				<-doing
-
-				if received == sent {
-					close(mapChan)
-					return ret
-				}
-			}
-		}
-	} else {
-		// max concurenccy is 0, means no limit
-		// so we only start reading the result chan here
-		for k, v := range c {
-			go cb(k, v, mapChan)
-		}
-
-		ct := 0
-		for intf := range mapChan {
-
-			ret[intf[0].(string)] = intf[1].(float32)
-
-			ct++
-			if ct == len(c) {
-				close(mapChan)
-			}
-		}
-	}
+	var ret = make(MapStringFloat32, len(c))
+	mapAsyncIntf(c, cb, maxConcurrency, func(k string, v float32) {
+		ret[k] = v
+	})
 	return ret
 }
 
-// MapAsyncInterface method creates a new slice with the results of calling a provided go routine on every element in the calling array.
-// Runs asynchronously and needs gives a chan []interface{} to return results.
-// To keep initial order, the first elemt of th []interface{} written to the chan must be the key. The second element muse be a string.
-// Returns InterfaceSlice.
-// If you know the result will be of original type, user MapAsync.
-// @Todo implement max concurrency (in case a lot of requests for example)
-func (c MapStringFloat32) MapAsyncInterface(cb func(string, float32, chan [2]interface{}), maxConcurrency ...int) MapStringInterface {
-	var maxConc = lists.DEFAULT_CONC
-	if len(maxConcurrency) == 1 {
-		maxConc = maxConcurrency[0]
-	}
-
-	mapChan := make(chan [2]interface{}, len(c))
-	ret := make(map[string]interface{}, len(c))
-
-	// if maxConc is higher than 0 length of chan doing is lower than maxConc && counter is lower than lenght of slice continue
-	// else start reading from the chan to decrease concurrency
-	if maxConc > 0 {
-
-		sent := 0
-		received := 0
-		doing := make(chan struct{}, maxConc)
-		indexes := c.Indexes()
-
-		for {
-
-			var k string
-			var v float32
-			if len(indexes) > sent {
-				k = indexes[sent]
-				v = c[k]
-			}
-
-			if len(doing) < maxConc && sent < len(c) {
-				go cb(k, v, mapChan)
-				sent++
-				if maxConc > 0 {
-					doing <- struct{}{}
-				}
-			} else {
-
-				// start reading my chan
-				intf := <-mapChan
-				received++
-
-				ret[intf[0].(string)] = intf[1]
-
-				// reading doing to continue the loop
-				<-doing
+// MapAsyncContext method is MapAsync with a context given to every go routine.
+// When the context is done, no more go routine is started and MapAsyncContext returns ctx.Err() with the results received so far.
+func (c MapStringFloat32) MapAsyncContext(ctx context.Context, cb func(context.Context, string, float32, chan [2]interface{}), opts lists.Options) (MapStringFloat32, error) {
+	var ret = make(MapStringFloat32, len(c))
+	err := mapAsyncIntfContext(ctx, c, cb, opts, func(k string, v float32) {
+		ret[k] = v
+	})
+	return ret, err
+}
 
-				if received == sent {
-					close(mapChan)
-					return ret
-				}
-			}
-		}
-	} else {
-		// max concurenccy is 0, means no limit
-		// so we only start reading the result chan here
-		for k, v := range c {
-			go cb(k, v, mapChan)
-		}
+// MapAsyncErr method creates a new map with the values returned by calling a provided func as a go routine on every element in the calling map.
+// Errors returned by the func are collected as set by opts.ErrorMode and wrapped in a *lists.ElementError, elements which failed are left out of the map.
+// The context is given to every go routine, when it is done no more go routine is started.
+func (c MapStringFloat32) MapAsyncErr(ctx context.Context, cb func(context.Context, string, float32) (float32, error), opts lists.Options) (MapStringFloat32, error) {
+	return MapValuesAsyncErrTo(ctx, c, cb, opts)
+}
 
-		ct := 0
-		for intf := range mapChan {
+// MapAsyncStream method calls a provided go routine on every element in the calling map and returns a chan yielding the results as soon as they are written.
+// Maps have no order, opts.Ordered is ignored.
+// The chan is closed once every go routine is done, the error of the call (nil on success) is then written to the error chan.
+func (c MapStringFloat32) MapAsyncStream(ctx context.Context, cb func(context.Context, string, float32, chan<- lists.Result[string, float32]), opts lists.Options) (<-chan lists.Result[string, float32], <-chan error) {
+	return MapValuesAsyncStreamTo(ctx, c, cb, opts)
+}
 
-			ret[intf[0].(string)] = intf[1]
+// MapParallel method creates a new map with the values returned by calling a provided func as a go routine on every element in the calling map.
+// No more than workers go routines run at the same time, 0 means no limit.
+// Returns a MapStringFloat32 (original type).
+func (c MapStringFloat32) MapParallel(cb func(string, float32) float32, workers int) MapStringFloat32 {
+	return MapValuesParallelTo(c, cb, workers)
+}
 
-			ct++
-			if ct == len(c) {
-				close(mapChan)
-			}
-		}
-	}
+// MapAsyncInterface method creates a new map with the results of calling a provided go routine on every element in the calling map.
+// Runs asynchronously and gives a chan [2]interface{} to return results.
+// The first element of the [2]interface{} written to the chan must be the key.
+// Returns a MapStringInterface.
+// If you know the result will be of original type, use MapAsync.
+func (c MapStringFloat32) MapAsyncInterface(cb func(string, float32, chan [2]interface{}), maxConcurrency ...int) MapStringInterface {
+	var ret = make(MapStringInterface, len(c))
+	mapAsyncIntf(c, cb, maxConcurrency, func(k string, v interface{}) {
+		ret[k] = v
+	})
 	return ret
 }
 
-// Reduce method applies a func against an accumulator and each element in the slice (from left to right) to reduce it to a single value of any type.
+// MapAsyncInterfaceContext method is MapAsyncInterface with a context given to every go routine.
+// When the context is done, no more go routine is started and MapAsyncInterfaceContext returns ctx.Err() with the results received so far.
+func (c MapStringFloat32) MapAsyncInterfaceContext(ctx context.Context, cb func(context.Context, string, float32, chan [2]interface{}), opts lists.Options) (MapStringInterface, error) {
+	var ret = make(MapStringInterface, len(c))
+	err := mapAsyncIntfContext(ctx, c, cb, opts, func(k string, v interface{}) {
+		ret[k] = v
+	})
+	return ret, err
+}
+
+// Reduce method applies a func against an accumulator and each element in the map to reduce it to a single value of any type.
 // If no accumulator is passed as second argument, default accumulator will be nil
 // Returns an interface.
 // For asynchronicity, see ReduceAsync.
 func (c MapStringFloat32) Reduce(cb func(string, float32, interface{}) interface{}, defAgg ...interface{}) interface{} {
 	var agg interface{}
-	if len(defAgg) == 0 {
-		agg = nil
-	} else {
+	if len(defAgg) > 0 {
 		agg = defAgg[0]
 	}
-	for k, v := range c {
-		agg = cb(k, v, agg)
-	}
-	return agg
+	return Reduce(c, cb, agg)
 }
 
-// Reduce method applies a go routinge against an accumulator and each element in the slice (from left to right) to reduce it to a single value of any type.
+// ReduceAsync method applies a go routine against an accumulator and each element in the map to reduce it to a single value of any type.
+// You must read the aggregator from the lists.AsyncAggregator.Agg channel before writing to the lists.AsyncAggregator.Done channel.
+// Go routines are run in series waiting for the previous go routine writing to the lists.AsyncAggregator.Done channel.
 // Returns an interface.
 // For synchronicity, see Reduce.
 func (c MapStringFloat32) ReduceAsync(cb func(string, float32, *lists.AsyncAggregator), defAgg ...interface{}) interface{} {
-	agg := &lists.AsyncAggregator{
-		Done: make(chan interface{}, len(c)),
-		Agg:  make(chan interface{}, len(c)),
-	}
-	if len(defAgg) == 0 {
-		agg.Agg <- nil
-	} else {
-		agg.Agg <- defAgg[0]
-	}
-	for k, v := range c {
-		go cb(k, v, agg)
-		agg.Agg <- <-agg.Done
+	var agg interface{}
+	if len(defAgg) > 0 {
+		agg = defAgg[0]
 	}
-	return <-agg.Agg
+	return ReduceAsync(c, cb, agg)
+}
+
+// ReduceAsyncContext method is ReduceAsync with a context given to every go routine.
+// When the context is done, no more go routine is started and the current state of the accumulator is returned with ctx.Err().
+// Panics are recovered as set by opts.OnPanic, the state of the accumulator is left unchanged by a go routine which panicked.
+func (c MapStringFloat32) ReduceAsyncContext(ctx context.Context, cb func(context.Context, string, float32, *lists.AsyncAggregator), agg interface{}, opts lists.Options) (interface{}, error) {
+	return ReduceAsyncContext(ctx, c, cb, agg, opts)
 }
 
-// Indexes returns a slice of ints with including the indexes of the StringSlice
+// Indexes returns a slice including the indexes (keys) of the MapStringFloat32
 func (c MapStringFloat32) Indexes() []string {
-	var indexes = []string{}
-	for k, _ := range c {
-		indexes = append(indexes, k)
-	}
-	return indexes
+	return Map[string, float32](c).Indexes()
 }
 
-// Filter method creates a new slice with all elements that pass the test implemented by the provided function.
+// Filter method creates a new map with all elements that pass the test implemented by the provided function.
 func (c MapStringFloat32) Filter(cb func(k string, v float32) bool) MapStringFloat32 {
-	var ret = make(map[string]float32, 0)
-	for k, v := range c {
-		if cb(k, v) {
-			ret[k] = v
-		}
-	}
-	return ret
+	return MapStringFloat32(Map[string, float32](c).Filter(cb))
 }
 
-// Cast explicitly cast the StringSlice to a map[string]string type
+// Cast explicitly cast the MapStringFloat32 to a map[string]float32 type
 func (c MapStringFloat32) Cast() map[string]float32 {
-	var dest map[string]float32
-	dest = c
-	return dest
+	return c
 }
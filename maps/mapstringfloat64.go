@@ -1,267 +1,149 @@
 package maps
 
-import "github.com/francoispqt/lists"
+import (
+	"context"
 
+	"github.com/francoispqt/lists"
+)
+
+// MapStringFloat64 is a custom type for a map[string]float64.
+// It is kept for compatibility, its methods delegate to Map[string, float64].
 type MapStringFloat64 map[string]float64
 
-// Contains method determines whether a slice includes a certain element, returning true or false as appropriate.
+// Contains method determines whether a map includes a certain element, returning true or false as appropriate.
 func (c MapStringFloat64) Contains(s float64) bool {
-	for _, v := range c {
-		if v == s {
-			return true
-		}
-	}
-	return false
+	return contains(c, s)
 }
 
-// ForEach method executes a provided func once for each slice element.
+// ForEach method executes a provided func once for each map element.
 func (c MapStringFloat64) ForEach(cb func(string, float64)) {
-	for k, v := range c {
-		cb(k, v)
-	}
+	Map[string, float64](c).ForEach(cb)
 }
 
-// MapInterface method creates a new slice with the results of calling a provided func on every element in the calling array.
-// Returns a slice of string (original type).
+// Map method creates a new map with the results of calling a provided func on every element in the calling map.
+// Returns a MapStringFloat64 (original type).
 // For asynchronicity, see MapAsync.
 func (c MapStringFloat64) Map(cb func(string, float64) float64) MapStringFloat64 {
-	var ret = make(map[string]float64, len(c))
-	for k, v := range c {
-		ret[k] = cb(k, v)
-	}
-	return ret
+	return MapStringFloat64(Map[string, float64](c).Map(cb))
 }
 
-// MapInterface method creates a new slice with the results of calling a provided func on every element in the calling array.
-// Returns a slice of interfaces.
+// MapInterface method creates a new map with the results of calling a provided func on every element in the calling map.
+// Returns a MapStringInterface.
 // For asynchronicity, see MapAsyncInterface.
 func (c MapStringFloat64) MapInterface(cb func(string, float64) interface{}) MapStringInterface {
-	var ret = make(map[string]interface{}, len(c))
-	for k, v := range c {
-		ret[k] = cb(k, v)
-	}
-	return ret
+	return MapStringInterface(Map[string, float64](c).MapInterface(cb))
 }
 
-// MapAsync method creates a new slice with the results of calling a provided go routine on every element in the calling array.
-// Runs asynchronously and needs gives a chan []interface{} to return results.
-// To keep initial order, the first elemt of th []interface{} written to the chan must be the key. The second element muse be a string.
-// Returns a StringSlice (original type).
-// If you want to map to a slice of different type, see MapAsyncInterface.
+// MapAsync method creates a new map with the results of calling a provided go routine on every element in the calling map.
+// Runs asynchronously and gives a chan [2]interface{} to return results.
+// The first element of the [2]interface{} written to the chan must be the key. The second element must be a float64.
+// Returns a MapStringFloat64 (original type).
+// For a typed chan, see Map.MapAsync.
 func (c MapStringFloat64) MapAsync(cb func(string, float64, chan [2]interface{}), maxConcurrency ...int) MapStringFloat64 {
-	var maxConc = lists.DEFAULT_CONC
-	if len(maxConcurrency) == 1 {
-		maxConc = maxConcurrency[0]
-	}
-
-	mapChan := make(chan [2]interface{}, len(c))
-	ret := make(map[string]float64, len(c))
-
-	// if maxConc is higher than 0 length of chan doing is lower than maxConc && counter is lower than lenght of slice continue
-	// else start reading from the chan to decrease concurrency
-	if maxConc > 0 {
-
-		sent := 0
-		received := 0
-		doing := make(chan struct{}, maxConc)
-		indexes := c.Indexes()
-
-		for {
-
-			var k string
-			var v float64
-			if len(indexes) > sent {
-				k = indexes[sent]
-				v = c[k]
-			}
-
-			if len(doing) < maxConc && sent < len(c) {
-				go cb(k, v, mapChan)
-				sent++
-				if maxConc > 0 {
-					doing <- struct{}{}
-				}
-			} else {
-
-				// start reading my chan
-				intf := <-mapChan
-				received++
-
-				if len(intf) > 1 {
-					ret[intf[0].(string)] = intf[1].(float64)
-				} else {
-					ret[intf[0].(string)] = 0
-				}
-
-				// reading doing to continue the loop
-				<-doing
-
-				if received == sent {
-					close(mapChan)
-					return ret
-				}
-			}
-		}
-	} else {
-		// max concurenccy is 0, means no limit
-		// so we only start reading the result chan here
-		for k, v := range c {
-			go cb(k, v, mapChan)
-		}
-
-		ct := 0
-		for intf := range mapChan {
-			if len(intf) > 1 {
-				ret[intf[0].(string)] = intf[1].(float64)
-			} else {
-				ret[intf[0].(string)] = 0
-			}
-			ct++
-			if ct == len(c) {
-				close(mapChan)
-			}
-		}
-	}
+	var ret = make(MapStringFloat64, len(c))
+	mapAsyncIntf(c, cb, maxConcurrency, func(k string, v float64) {
+		ret[k] = v
+	})
 	return ret
 }
 
-// MapAsyncInterface method creates a new slice with the results of calling a provided go routine on every element in the calling array.
-// Runs asynchronously and needs gives a chan []interface{} to return results.
-// To keep initial order, the first elemt of th []interface{} written to the chan must be the key. The second element muse be a string.
-// Returns InterfaceSlice.
-// If you know the result will be of original type, user MapAsync.
-// @Todo implement max concurrency (in case a lot of requests for example)
-func (c MapStringFloat64) MapAsyncInterface(cb func(string, float64, chan [2]interface{}), maxConcurrency ...int) MapStringInterface {
-	var maxConc = lists.DEFAULT_CONC
-	if len(maxConcurrency) == 1 {
-		maxConc = maxConcurrency[0]
-	}
-
-	mapChan := make(chan [2]interface{}, len(c))
-	ret := make(map[string]interface{}, len(c))
-
-	// if maxConc is higher than 0 length of chan doing is lower than maxConc && counter is lower than lenght of slice continue
-	// else start reading from the chan to decrease concurrency
-	if maxConc > 0 {
-
-		sent := 0
-		received := 0
-		doing := make(chan struct{}, maxConc)
-		indexes := c.Indexes()
-
-		for {
-
-			var k string
-			var v float64
-			if len(indexes) > sent {
-				k = indexes[sent]
-				v = c[k]
-			}
-
-			if len(doing) < maxConc && sent < len(c) {
-				go cb(k, v, mapChan)
-				sent++
-				if maxConc > 0 {
-					doing <- struct{}{}
-				}
-			} else {
-
-				// start reading my chan
-				intf := <-mapChan
-				received++
-
-				ret[intf[0].(string)] = intf[1]
-
-				// reading doing to continue the loop
-				<-doing
+// MapAsyncContext method is MapAsync with a context given to every go routine.
+// When the context is done, no more go routine is started and MapAsyncContext returns ctx.Err() with the results received so far.
+func (c MapStringFloat64) MapAsyncContext(ctx context.Context, cb func(context.Context, string, float64, chan [2]interface{}), opts lists.Options) (MapStringFloat64, error) {
+	var ret = make(MapStringFloat64, len(c))
+	err := mapAsyncIntfContext(ctx, c, cb, opts, func(k string, v float64) {
+		ret[k] = v
+	})
+	return ret, err
+}
 
-				if received == sent {
-					close(mapChan)
-					return ret
-				}
-			}
-		}
-	} else {
-		// max concurenccy is 0, means no limit
-		// so we only start reading the result chan here
-		for k, v := range c {
-			go cb(k, v, mapChan)
-		}
+// MapAsyncErr method creates a new map with the values returned by calling a provided func as a go routine on every element in the calling map.
+// Errors returned by the func are collected as set by opts.ErrorMode and wrapped in a *lists.ElementError, elements which failed are left out of the map.
+// The context is given to every go routine, when it is done no more go routine is started.
+func (c MapStringFloat64) MapAsyncErr(ctx context.Context, cb func(context.Context, string, float64) (float64, error), opts lists.Options) (MapStringFloat64, error) {
+	return MapValuesAsyncErrTo(ctx, c, cb, opts)
+}
 
-		ct := 0
-		for intf := range mapChan {
+// MapAsyncStream method calls a provided go routine on every element in the calling map and returns a chan yielding the results as soon as they are written.
+// Maps have no order, opts.Ordered is ignored.
+// The chan is closed once every go routine is done, the error of the call (nil on success) is then written to the error chan.
+func (c MapStringFloat64) MapAsyncStream(ctx context.Context, cb func(context.Context, string, float64, chan<- lists.Result[string, float64]), opts lists.Options) (<-chan lists.Result[string, float64], <-chan error) {
+	return MapValuesAsyncStreamTo(ctx, c, cb, opts)
+}
 
-			ret[intf[0].(string)] = intf[1]
+// MapParallel method creates a new map with the values returned by calling a provided func as a go routine on every element in the calling map.
+// No more than workers go routines run at the same time, 0 means no limit.
+// Returns a MapStringFloat64 (original type).
+func (c MapStringFloat64) MapParallel(cb func(string, float64) float64, workers int) MapStringFloat64 {
+	return MapValuesParallelTo(c, cb, workers)
+}
 
-			ct++
-			if ct == len(c) {
-				close(mapChan)
-			}
-		}
-	}
+// MapAsyncInterface method creates a new map with the results of calling a provided go routine on every element in the calling map.
+// Runs asynchronously and gives a chan [2]interface{} to return results.
+// The first element of the [2]interface{} written to the chan must be the key.
+// Returns a MapStringInterface.
+// If you know the result will be of original type, use MapAsync.
+func (c MapStringFloat64) MapAsyncInterface(cb func(string, float64, chan [2]interface{}), maxConcurrency ...int) MapStringInterface {
+	var ret = make(MapStringInterface, len(c))
+	mapAsyncIntf(c, cb, maxConcurrency, func(k string, v interface{}) {
+		ret[k] = v
+	})
 	return ret
 }
 
-// Reduce method applies a func against an accumulator and each element in the slice (from left to right) to reduce it to a single value of any type.
+// MapAsyncInterfaceContext method is MapAsyncInterface with a context given to every go routine.
+// When the context is done, no more go routine is started and MapAsyncInterfaceContext returns ctx.Err() with the results received so far.
+func (c MapStringFloat64) MapAsyncInterfaceContext(ctx context.Context, cb func(context.Context, string, float64, chan [2]interface{}), opts lists.Options) (MapStringInterface, error) {
+	var ret = make(MapStringInterface, len(c))
+	err := mapAsyncIntfContext(ctx, c, cb, opts, func(k string, v interface{}) {
+		ret[k] = v
+	})
+	return ret, err
+}
+
+// Reduce method applies a func against an accumulator and each element in the map to reduce it to a single value of any type.
 // If no accumulator is passed as second argument, default accumulator will be nil
 // Returns an interface.
 // For asynchronicity, see ReduceAsync.
 func (c MapStringFloat64) Reduce(cb func(string, float64, interface{}) interface{}, defAgg ...interface{}) interface{} {
 	var agg interface{}
-	if len(defAgg) == 0 {
-		agg = nil
-	} else {
+	if len(defAgg) > 0 {
 		agg = defAgg[0]
 	}
-	for k, v := range c {
-		agg = cb(k, v, agg)
-	}
-	return agg
+	return Reduce(c, cb, agg)
 }
 
-// Reduce method applies a go routinge against an accumulator and each element in the slice (from left to right) to reduce it to a single value of any type.
+// ReduceAsync method applies a go routine against an accumulator and each element in the map to reduce it to a single value of any type.
+// You must read the aggregator from the lists.AsyncAggregator.Agg channel before writing to the lists.AsyncAggregator.Done channel.
+// Go routines are run in series waiting for the previous go routine writing to the lists.AsyncAggregator.Done channel.
 // Returns an interface.
 // For synchronicity, see Reduce.
 func (c MapStringFloat64) ReduceAsync(cb func(string, float64, *lists.AsyncAggregator), defAgg ...interface{}) interface{} {
-	agg := &lists.AsyncAggregator{
-		Done: make(chan interface{}, len(c)),
-		Agg:  make(chan interface{}, len(c)),
-	}
-	if len(defAgg) == 0 {
-		agg.Agg <- nil
-	} else {
-		agg.Agg <- defAgg[0]
-	}
-	for k, v := range c {
-		go cb(k, v, agg)
-		agg.Agg <- <-agg.Done
+	var agg interface{}
+	if len(defAgg) > 0 {
+		agg = defAgg[0]
 	}
-	return <-agg.Agg
+	return ReduceAsync(c, cb, agg)
+}
+
+// ReduceAsyncContext method is ReduceAsync with a context given to every go routine.
+// When the context is done, no more go routine is started and the current state of the accumulator is returned with ctx.Err().
+// Panics are recovered as set by opts.OnPanic, the state of the accumulator is left unchanged by a go routine which panicked.
+func (c MapStringFloat64) ReduceAsyncContext(ctx context.Context, cb func(context.Context, string, float64, *lists.AsyncAggregator), agg interface{}, opts lists.Options) (interface{}, error) {
+	return ReduceAsyncContext(ctx, c, cb, agg, opts)
 }
 
-// Indexes returns a slice of ints with including the indexes of the StringSlice
+// Indexes returns a slice including the indexes (keys) of the MapStringFloat64
 func (c MapStringFloat64) Indexes() []string {
-	var indexes = []string{}
-	for k, _ := range c {
-		indexes = append(indexes, k)
-	}
-	return indexes
+	return Map[string, float64](c).Indexes()
 }
 
-// Filter method creates a new slice with all elements that pass the test implemented by the provided function.
+// Filter method creates a new map with all elements that pass the test implemented by the provided function.
 func (c MapStringFloat64) Filter(cb func(k string, v float64) bool) MapStringFloat64 {
-	var ret = make(map[string]float64, 0)
-	for k, v := range c {
-		if cb(k, v) {
-			ret[k] = v
-		}
-	}
-	return ret
+	return MapStringFloat64(Map[string, float64](c).Filter(cb))
 }
 
-// Cast explicitly cast the StringSlice to a map[string]string type
+// Cast explicitly cast the MapStringFloat64 to a map[string]float64 type
 func (c MapStringFloat64) Cast() map[string]float64 {
-	var dest map[string]float64
-	dest = c
-	return dest
+	return c
 }
@@ -1,200 +1,120 @@
 package maps
 
 import (
-	"reflect"
+	"context"
 
 	"github.com/francoispqt/lists"
 )
 
-// InterfaceSlice is a custom type for a slice of interface{}
+// MapStringInterface is a custom type for a map[string]interface{}.
+// It is kept for compatibility, its methods delegate to Map[string, interface{}].
 type MapStringInterface map[string]interface{}
 
-// Contains method determines whether a slice includes a certain element, returning true or false as appropriate.
+// Contains method determines whether a map includes a certain element, returning true or false as appropriate.
+// Slices and arrays are compared element by element.
 func (c MapStringInterface) Contains(s interface{}) bool {
-	contains := false
-	for _, v := range c {
-		val := reflect.ValueOf(v)
-		sVal := reflect.ValueOf(s)
-		switch val.Kind() {
-		case reflect.Slice:
-			if sVal.Kind() == reflect.Slice {
-				contains = compare(v, s)
-				if contains {
-					return true
-				}
-			}
-			continue
-		case reflect.Array:
-			if sVal.Kind() == reflect.Array {
-				contains = compare(v, s)
-				if contains {
-					return true
-				}
-			}
-			continue
-		default:
-			if v == s {
-				return true
-			}
-		}
-	}
-	return contains
+	return Map[string, interface{}](c).Contains(s)
 }
 
-// ForEach method executes a provided func once for each slice element.
+// ForEach method executes a provided func once for each map element.
 func (c MapStringInterface) ForEach(cb func(string, interface{})) {
-	for k, v := range c {
-		cb(k, v)
-	}
+	Map[string, interface{}](c).ForEach(cb)
 }
 
-// MapInterface method creates a new slice with the results of calling a provided func on every element in the calling array.
-// Returns a slice of string (original type).
+// Map method creates a new map with the results of calling a provided func on every element in the calling map.
+// Returns a MapStringInterface (original type).
 // For asynchronicity, see MapAsync.
 func (c MapStringInterface) Map(cb func(string, interface{}) interface{}) MapStringInterface {
-	var ret = make(map[string]interface{}, len(c))
-	for k, v := range c {
-		ret[k] = cb(k, v)
-	}
-	return ret
+	return MapStringInterface(Map[string, interface{}](c).Map(cb))
 }
 
-// MapAsync method creates a new slice with the results of calling a provided go routine on every element in the calling array.
-// Runs asynchronously and needs gives a chan []interface{} to return results.
-// To keep initial order, the first elemt of th []interface{} written to the chan must be the key. The second element muse be a string.
-// Returns a StringSlice (original type).
-// If you want to map to a slice of different type, see MapAsyncInterface.
+// MapAsync method creates a new map with the results of calling a provided go routine on every element in the calling map.
+// Runs asynchronously and gives a chan [2]interface{} to return results.
+// The first element of the [2]interface{} written to the chan must be the key. The second element must be an interface{}.
+// Returns a MapStringInterface (original type).
+// For a typed chan, see Map.MapAsync.
 func (c MapStringInterface) MapAsync(cb func(string, interface{}, chan [2]interface{}), maxConcurrency ...int) MapStringInterface {
-	var maxConc = lists.DEFAULT_CONC
-	if len(maxConcurrency) == 1 {
-		maxConc = maxConcurrency[0]
-	}
-
-	mapChan := make(chan [2]interface{}, len(c))
-	ret := make(map[string]interface{}, len(c))
-
-	// if maxConc is higher than 0 length of chan doing is lower than maxConc && counter is lower than lenght of slice continue
-	// else start reading from the chan to decrease concurrency
-	if maxConc > 0 {
-
-		sent := 0
-		received := 0
-		doing := make(chan struct{}, maxConc)
-		indexes := c.Indexes()
-
-		for {
-
-			var k string
-			var v interface{}
-			if len(indexes) > sent {
-				k = indexes[sent]
-				v = c[k]
-			}
-
-			if len(doing) < maxConc && sent < len(c) {
-				go cb(k, v, mapChan)
-				sent++
-				if maxConc > 0 {
-					doing <- struct{}{}
-				}
-			} else {
-
-				// start reading my chan
-				intf := <-mapChan
-				received++
-
-				ret[intf[0].(string)] = intf[1]
-
-				// reading doing to continue the loop
-				<-doing
-
-				if received == sent {
-					close(mapChan)
-					return ret
-				}
-			}
-		}
-	} else {
-		// max concurenccy is 0, means no limit
-		// so we only start reading the result chan here
-		for k, v := range c {
-			go cb(k, v, mapChan)
-		}
-
-		ct := 0
-		for intf := range mapChan {
-
-			ret[intf[0].(string)] = intf[1]
-
-			ct++
-			if ct == len(c) {
-				close(mapChan)
-			}
-		}
-	}
+	var ret = make(MapStringInterface, len(c))
+	mapAsyncIntf(c, cb, maxConcurrency, func(k string, v interface{}) {
+		ret[k] = v
+	})
 	return ret
 }
 
-// Reduce method applies a func against an accumulator and each element in the slice (from left to right) to reduce it to a single value of any type.
+// MapAsyncContext method is MapAsync with a context given to every go routine.
+// When the context is done, no more go routine is started and MapAsyncContext returns ctx.Err() with the results received so far.
+func (c MapStringInterface) MapAsyncContext(ctx context.Context, cb func(context.Context, string, interface{}, chan [2]interface{}), opts lists.Options) (MapStringInterface, error) {
+	var ret = make(MapStringInterface, len(c))
+	err := mapAsyncIntfContext(ctx, c, cb, opts, func(k string, v interface{}) {
+		ret[k] = v
+	})
+	return ret, err
+}
+
+// MapAsyncErr method creates a new map with the values returned by calling a provided func as a go routine on every element in the calling map.
+// Errors returned by the func are collected as set by opts.ErrorMode and wrapped in a *lists.ElementError, elements which failed are left out of the map.
+// The context is given to every go routine, when it is done no more go routine is started.
+func (c MapStringInterface) MapAsyncErr(ctx context.Context, cb func(context.Context, string, interface{}) (interface{}, error), opts lists.Options) (MapStringInterface, error) {
+	return MapValuesAsyncErrTo(ctx, c, cb, opts)
+}
+
+// MapAsyncStream method calls a provided go routine on every element in the calling map and returns a chan yielding the results as soon as they are written.
+// Maps have no order, opts.Ordered is ignored.
+// The chan is closed once every go routine is done, the error of the call (nil on success) is then written to the error chan.
+func (c MapStringInterface) MapAsyncStream(ctx context.Context, cb func(context.Context, string, interface{}, chan<- lists.Result[string, interface{}]), opts lists.Options) (<-chan lists.Result[string, interface{}], <-chan error) {
+	return MapValuesAsyncStreamTo(ctx, c, cb, opts)
+}
+
+// MapParallel method creates a new map with the values returned by calling a provided func as a go routine on every element in the calling map.
+// No more than workers go routines run at the same time, 0 means no limit.
+// Returns a MapStringInterface (original type).
+func (c MapStringInterface) MapParallel(cb func(string, interface{}) interface{}, workers int) MapStringInterface {
+	return MapValuesParallelTo(c, cb, workers)
+}
+
+// Reduce method applies a func against an accumulator and each element in the map to reduce it to a single value of any type.
 // If no accumulator is passed as second argument, default accumulator will be nil
 // Returns an interface.
 // For asynchronicity, see ReduceAsync.
 func (c MapStringInterface) Reduce(cb func(string, interface{}, interface{}) interface{}, defAgg ...interface{}) interface{} {
 	var agg interface{}
-	if len(defAgg) == 0 {
-		agg = nil
-	} else {
+	if len(defAgg) > 0 {
 		agg = defAgg[0]
 	}
-	for k, v := range c {
-		agg = cb(k, v, agg)
-	}
-	return agg
+	return Reduce(c, cb, agg)
 }
 
-// Reduce method applies a go routinge against an accumulator and each element in the slice (from left to right) to reduce it to a single value of any type.
+// ReduceAsync method applies a go routine against an accumulator and each element in the map to reduce it to a single value of any type.
+// You must read the aggregator from the lists.AsyncAggregator.Agg channel before writing to the lists.AsyncAggregator.Done channel.
+// Go routines are run in series waiting for the previous go routine writing to the lists.AsyncAggregator.Done channel.
 // Returns an interface.
 // For synchronicity, see Reduce.
 func (c MapStringInterface) ReduceAsync(cb func(string, interface{}, *lists.AsyncAggregator), defAgg ...interface{}) interface{} {
-	agg := &lists.AsyncAggregator{
-		Done: make(chan interface{}, len(c)),
-		Agg:  make(chan interface{}, len(c)),
-	}
-	if len(defAgg) == 0 {
-		agg.Agg <- nil
-	} else {
-		agg.Agg <- defAgg[0]
-	}
-	for k, v := range c {
-		go cb(k, v, agg)
-		agg.Agg <- <-agg.Done
+	var agg interface{}
+	if len(defAgg) > 0 {
+		agg = defAgg[0]
 	}
-	return <-agg.Agg
+	return ReduceAsync(c, cb, agg)
+}
+
+// ReduceAsyncContext method is ReduceAsync with a context given to every go routine.
+// When the context is done, no more go routine is started and the current state of the accumulator is returned with ctx.Err().
+// Panics are recovered as set by opts.OnPanic, the state of the accumulator is left unchanged by a go routine which panicked.
+func (c MapStringInterface) ReduceAsyncContext(ctx context.Context, cb func(context.Context, string, interface{}, *lists.AsyncAggregator), agg interface{}, opts lists.Options) (interface{}, error) {
+	return ReduceAsyncContext(ctx, c, cb, agg, opts)
 }
 
-// Indexes returns a slice of ints with including the indexes of the StringSlice
+// Indexes returns a slice including the indexes (keys) of the MapStringInterface
 func (c MapStringInterface) Indexes() []string {
-	var indexes = []string{}
-	for k, _ := range c {
-		indexes = append(indexes, k)
-	}
-	return indexes
+	return Map[string, interface{}](c).Indexes()
 }
 
-// Filter method creates a new slice with all elements that pass the test implemented by the provided function.
+// Filter method creates a new map with all elements that pass the test implemented by the provided function.
 func (c MapStringInterface) Filter(cb func(k string, v interface{}) bool) MapStringInterface {
-	var ret = make(map[string]interface{}, 0)
-	for k, v := range c {
-		if cb(k, v) {
-			ret[k] = v
-		}
-	}
-	return ret
+	return MapStringInterface(Map[string, interface{}](c).Filter(cb))
 }
 
-// Cast explicitly cast the StringSlice to a map[string]string type
+// Cast explicitly cast the MapStringInterface to a map[string]interface{} type
 func (c MapStringInterface) Cast() map[string]interface{} {
-	var dest map[string]interface{}
-	dest = c
-	return dest
+	return c
 }
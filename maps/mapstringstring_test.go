@@ -35,7 +35,7 @@ func TestHeavyLifting(t *testing.T) {
 		go func() {
 			// build uri
 			uri := fmt.Sprintf("https://jsonplaceholder.typicode.com/comments/%s", k)
-			log.Printf("calling :", "GET/"+uri)
+			log.Printf("calling : %s", "GET/"+uri)
 
 			// make get request
 			rs, err := http.Get(uri)
@@ -51,7 +51,7 @@ func TestHeavyLifting(t *testing.T) {
 			}
 
 			bodyString := string(bodyBytes)
-			log.Printf("got response :", uri)
+			log.Printf("got response : %s", uri)
 			// write response to channel
 			// index must be first element
 			done <- [2]string{k, bodyString}
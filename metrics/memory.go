@@ -0,0 +1,139 @@
+package metrics
+
+import (
+	"sort"
+	"sync"
+)
+
+// MemoryRegistry is a Registry keeping the values of its metrics in memory, for tests.
+// Registering a name twice returns the same metric.
+type MemoryRegistry struct {
+	mu         sync.Mutex
+	values     map[string]*memoryValue
+	histograms map[string]*MemoryHistogram
+}
+
+// NewMemoryRegistry returns an empty MemoryRegistry.
+func NewMemoryRegistry() *MemoryRegistry {
+	return &MemoryRegistry{
+		values:     make(map[string]*memoryValue),
+		histograms: make(map[string]*MemoryHistogram),
+	}
+}
+
+// Counter returns the counter of the given name.
+func (r *MemoryRegistry) Counter(name, help string) Counter {
+	return r.value(name)
+}
+
+// Gauge returns the gauge of the given name.
+func (r *MemoryRegistry) Gauge(name, help string) Gauge {
+	return r.value(name)
+}
+
+// Histogram returns the histogram of the given name.
+func (r *MemoryRegistry) Histogram(name, help string, buckets []float64) Histogram {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	h, ok := r.histograms[name]
+	if !ok {
+		h = &MemoryHistogram{buckets: append([]float64(nil), buckets...)}
+		sort.Float64s(h.buckets)
+		h.counts = make([]uint64, len(h.buckets))
+		r.histograms[name] = h
+	}
+	return h
+}
+
+// Value returns the value of the counter or the gauge of the given name, 0 if there is none.
+func (r *MemoryRegistry) Value(name string) float64 {
+	r.mu.Lock()
+	v, ok := r.values[name]
+	r.mu.Unlock()
+	if !ok {
+		return 0
+	}
+	return v.get()
+}
+
+// HistogramOf returns the histogram of the given name, nil if there is none.
+func (r *MemoryRegistry) HistogramOf(name string) *MemoryHistogram {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.histograms[name]
+}
+
+func (r *MemoryRegistry) value(name string) *memoryValue {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	v, ok := r.values[name]
+	if !ok {
+		v = &memoryValue{}
+		r.values[name] = v
+	}
+	return v
+}
+
+type memoryValue struct {
+	mu    sync.Mutex
+	value float64
+}
+
+func (v *memoryValue) Add(f float64) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.value += f
+}
+
+func (v *memoryValue) get() float64 {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.value
+}
+
+// MemoryHistogram is the Histogram of a MemoryRegistry.
+type MemoryHistogram struct {
+	mu      sync.Mutex
+	buckets []float64
+	counts  []uint64
+	count   uint64
+	sum     float64
+}
+
+// Observe adds an observation to the histogram.
+func (h *MemoryHistogram) Observe(f float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.count++
+	h.sum += f
+	for i, b := range h.buckets {
+		if f <= b {
+			h.counts[i]++
+		}
+	}
+}
+
+// Count returns the number of observations.
+func (h *MemoryHistogram) Count() uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.count
+}
+
+// Sum returns the sum of the observations.
+func (h *MemoryHistogram) Sum() float64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.sum
+}
+
+// Buckets returns the cumulative count of observations lower than or equal to each upper bound.
+func (h *MemoryHistogram) Buckets() map[float64]uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	ret := make(map[float64]uint64, len(h.buckets))
+	for i, b := range h.buckets {
+		ret[b] = h.counts[i]
+	}
+	return ret
+}
@@ -0,0 +1,31 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryRegistry(t *testing.T) {
+	reg := NewMemoryRegistry()
+
+	reg.Counter("count", "").Add(2)
+	reg.Counter("count", "").Add(3)
+	assert.Equal(t, 5.0, reg.Value("count"), "registering a name twice should return the same counter")
+
+	g := reg.Gauge("gauge", "")
+	g.Add(1)
+	g.Add(-1)
+	assert.Equal(t, 0.0, reg.Value("gauge"), "gauge should go up and down")
+	assert.Equal(t, 0.0, reg.Value("missing"), "missing metrics should be 0")
+	assert.Nil(t, reg.HistogramOf("missing"), "missing histogram should be nil")
+
+	h := reg.Histogram("latency", "", []float64{1, 0.1})
+	h.Observe(0.05)
+	h.Observe(0.5)
+	h.Observe(2)
+	hist := reg.HistogramOf("latency")
+	assert.Equal(t, uint64(3), hist.Count(), "3 observations should be counted")
+	assert.Equal(t, 2.55, hist.Sum(), "sum should be 2.55")
+	assert.Equal(t, map[float64]uint64{0.1: 1, 1: 2}, hist.Buckets(), "buckets should be cumulative")
+}
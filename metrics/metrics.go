@@ -0,0 +1,103 @@
+// Package metrics exposes the behaviour of async methods as counters, gauges and histograms registered in a pluggable Registry.
+//
+// The Observer of the package is given to async methods in lists.Options.Observer.
+// A Registry can be implemented on top of any metrics library, for instance with Prometheus:
+//
+//	type promRegistry struct{ reg prometheus.Registerer }
+//
+//	func (r promRegistry) Counter(name, help string) metrics.Counter {
+//		c := prometheus.NewCounter(prometheus.CounterOpts{Name: name, Help: help})
+//		r.reg.MustRegister(c)
+//		return c
+//	}
+//
+// NewMemoryRegistry returns a Registry keeping the values in memory, for tests.
+package metrics
+
+import (
+	"errors"
+	"time"
+
+	"github.com/francoispqt/lists"
+)
+
+// Counter is a value which only goes up.
+type Counter interface {
+	Add(float64)
+}
+
+// Gauge is a value which goes up and down.
+type Gauge interface {
+	Add(float64)
+}
+
+// Histogram counts observations in buckets.
+type Histogram interface {
+	Observe(float64)
+}
+
+// Registry creates the metrics of an Observer, it is called once per metric when the Observer is created.
+type Registry interface {
+	Counter(name, help string) Counter
+	Gauge(name, help string) Gauge
+	Histogram(name, help string, buckets []float64) Histogram
+}
+
+// DefBuckets are the buckets of the latency histogram, in seconds.
+var DefBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Names of the metrics of an Observer, after its prefix.
+const (
+	Processed = "elements_processed_total"
+	Failures  = "element_failures_total"
+	Latency   = "element_duration_seconds"
+	InFlight  = "elements_in_flight"
+	Aborted   = "elements_aborted_total"
+)
+
+// Observer is a lists.Observer recording the elements processed, the failures, the latency of elements,
+// the current concurrency of the async methods it is given to and the elements they aborted.
+type Observer struct {
+	processed Counter
+	failures  Counter
+	latency   Histogram
+	inFlight  Gauge
+	aborted   Counter
+}
+
+// NewObserver registers the metrics in reg, their names starting with prefix and an underscore if it is not empty.
+func NewObserver(reg Registry, prefix string) *Observer {
+	if prefix != "" {
+		prefix += "_"
+	}
+	return &Observer{
+		processed: reg.Counter(prefix+Processed, "Number of elements processed by async methods."),
+		failures:  reg.Counter(prefix+Failures, "Number of elements of async methods which failed."),
+		latency:   reg.Histogram(prefix+Latency, "Time taken by the elements of async methods, in seconds.", DefBuckets),
+		inFlight:  reg.Gauge(prefix+InFlight, "Number of elements of async methods running."),
+		aborted:   reg.Counter(prefix+Aborted, "Number of elements of async methods dropped as the method returned early."),
+	}
+}
+
+// OnStart counts the element as in flight.
+func (o *Observer) OnStart(key interface{}) {
+	o.inFlight.Add(1)
+}
+
+// OnElementDone counts the element as processed, and as a failure if err is not nil, and observes its latency.
+// An element reported as lists.ErrElementAborted is only counted as aborted.
+func (o *Observer) OnElementDone(key interface{}, d time.Duration, err error) {
+	o.inFlight.Add(-1)
+	if errors.Is(err, lists.ErrElementAborted) {
+		o.aborted.Add(1)
+		return
+	}
+	o.processed.Add(1)
+	if err != nil {
+		o.failures.Add(1)
+	}
+	o.latency.Observe(d.Seconds())
+}
+
+// OnComplete does nothing, the metrics are the ones of elements.
+func (o *Observer) OnComplete(err error) {}
@@ -0,0 +1,67 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/francoispqt/lists"
+	"github.com/francoispqt/lists/slices"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserver(t *testing.T) {
+	reg := NewMemoryRegistry()
+	observer := NewObserver(reg, "batch")
+
+	test := make(slices.IntSlice, 20)
+	_, err := test.MapAsyncErr(context.Background(), func(_ context.Context, k int, v int) (int, error) {
+		assert.True(t, reg.Value("batch_"+InFlight) >= 1, "the element should be in flight")
+		time.Sleep(time.Millisecond)
+		if k < 5 {
+			return 0, errors.New("boom")
+		}
+		return k, nil
+	}, lists.Options{MaxConcurrency: 4, ErrorMode: lists.JoinErrors, Observer: observer})
+	assert.NotNil(t, err, "err should not be nil")
+
+	assert.Equal(t, 20.0, reg.Value("batch_"+Processed), "every element should be processed")
+	assert.Equal(t, 5.0, reg.Value("batch_"+Failures), "5 elements should have failed")
+	assert.Equal(t, 0.0, reg.Value("batch_"+InFlight), "no element should be in flight")
+	latency := reg.HistogramOf("batch_" + Latency)
+	assert.Equal(t, uint64(20), latency.Count(), "latency of every element should be observed")
+	assert.True(t, latency.Sum() >= 0.02, "every element should take at least 1ms")
+
+	_, err = slices.Slice[int]{1, 2, 3}.ReduceAsyncContext(context.Background(), func(_ context.Context, k int, v int, agg *lists.Aggregator[int]) {
+		agg.Done <- <-agg.Agg + v
+	}, 0, lists.Options{Observer: observer})
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, 23.0, reg.Value("batch_"+Processed), "steps of reduce should be processed")
+}
+
+func TestObserverAborted(t *testing.T) {
+	reg := NewMemoryRegistry()
+	observer := NewObserver(reg, "batch")
+
+	release := make(chan struct{})
+	_, err := slices.IntSlice{0, 1, 2, 3}.MapAsyncErr(context.Background(), func(ctx context.Context, k int, v int) (int, error) {
+		if k == 0 {
+			return 0, errors.New("boom")
+		}
+		// the other elements are still running when the call returns
+		<-release
+		return k, nil
+	}, lists.Options{ErrorMode: lists.FirstError, Observer: observer})
+	assert.NotNil(t, err, "err should not be nil")
+	assert.Equal(t, 3.0, reg.Value("batch_"+InFlight), "elements still running should stay in flight")
+
+	close(release)
+	assert.Eventually(t, func() bool {
+		return reg.Value("batch_"+InFlight) == 0
+	}, time.Second, time.Millisecond, "no element should be in flight once their go routines returned")
+	assert.Equal(t, 3.0, reg.Value("batch_"+Aborted), "elements still running should be aborted")
+	assert.Equal(t, 1.0, reg.Value("batch_"+Processed), "aborted elements should not be processed")
+	assert.Equal(t, 1.0, reg.Value("batch_"+Failures), "aborted elements should not be failures")
+	assert.Equal(t, uint64(1), reg.HistogramOf("batch_"+Latency).Count(), "latency of aborted elements should not be observed")
+}
@@ -0,0 +1,100 @@
+package lists
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Observer is notified of the progress of the async map and reduce methods given it in Options.Observer.
+// Its methods are called from the go routine of the method, one at a time for a call, but for the elements
+// reported as ErrElementAborted, from their own go routine, possibly after OnComplete.
+// An Observer must be safe for concurrent use.
+type Observer interface {
+	// OnStart is called when the go routine of an element is started.
+	OnStart(key interface{})
+	// OnElementDone is called once for every element started, when it is done, with the time elapsed since its start
+	// and its error, nil if it succeeded. Elements still running when the method returns early, on an error or
+	// once its context is done, are reported with ErrElementAborted once their go routine returns.
+	OnElementDone(key interface{}, d time.Duration, err error)
+	// OnComplete is called when the method returns, with the error it returns.
+	OnComplete(err error)
+}
+
+// Progress is an Observer counting the elements completed, failed, aborted and in flight, for logging.
+// It can be shared by several calls, the counts are then the ones of all calls.
+type Progress struct {
+	mu        sync.Mutex
+	start     time.Time
+	completed int
+	failed    int
+	aborted   int
+	inFlight  int
+}
+
+// ProgressSnapshot holds the counts of a Progress at a given time.
+// Completed includes the Failed elements but not the Aborted ones, reported as ErrElementAborted.
+// Throughput is the number of elements completed per second since the first one started.
+type ProgressSnapshot struct {
+	Completed  int
+	Failed     int
+	Aborted    int
+	InFlight   int
+	Elapsed    time.Duration
+	Throughput float64
+}
+
+// NewProgress returns a Progress with all counts at 0.
+func NewProgress() *Progress {
+	return &Progress{}
+}
+
+// OnStart counts the element as in flight.
+func (p *Progress) OnStart(key interface{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.start.IsZero() {
+		p.start = time.Now()
+	}
+	p.inFlight++
+}
+
+// OnElementDone counts the element as completed, and failed if err is not nil, or as aborted if err is ErrElementAborted.
+func (p *Progress) OnElementDone(key interface{}, d time.Duration, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.inFlight--
+	if errors.Is(err, ErrElementAborted) {
+		p.aborted++
+		return
+	}
+	p.completed++
+	if err != nil {
+		p.failed++
+	}
+}
+
+// OnComplete does nothing.
+func (p *Progress) OnComplete(err error) {}
+
+// Snapshot returns the current counts.
+func (p *Progress) Snapshot() ProgressSnapshot {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	s := ProgressSnapshot{
+		Completed: p.completed,
+		Failed:    p.failed,
+		Aborted:   p.aborted,
+		InFlight:  p.inFlight,
+	}
+	if !p.start.IsZero() {
+		s.Elapsed = time.Since(p.start)
+		s.Throughput = float64(s.Completed) / s.Elapsed.Seconds()
+	}
+	return s
+}
+
+func (s ProgressSnapshot) String() string {
+	return fmt.Sprintf("%d completed (%d failed), %d aborted, %d in flight, %.1f/s", s.Completed, s.Failed, s.Aborted, s.InFlight, s.Throughput)
+}
@@ -0,0 +1,37 @@
+package lists
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgress(t *testing.T) {
+	progress := NewProgress()
+	assert.Equal(t, ProgressSnapshot{}, progress.Snapshot(), "counts should start at 0")
+
+	for i := 0; i < 4; i++ {
+		progress.OnStart(i)
+	}
+	progress.OnElementDone(0, time.Millisecond, nil)
+	progress.OnElementDone(1, time.Millisecond, errors.New("boom"))
+	time.Sleep(10 * time.Millisecond)
+
+	s := progress.Snapshot()
+	assert.Equal(t, 2, s.Completed, "2 elements should be completed")
+	assert.Equal(t, 1, s.Failed, "1 element should have failed")
+	assert.Equal(t, 2, s.InFlight, "2 elements should be in flight")
+	assert.True(t, s.Elapsed >= 10*time.Millisecond, "elapsed should be counted from the first start")
+	assert.True(t, s.Throughput > 0, "throughput should be computed")
+
+	progress.OnElementDone(2, time.Millisecond, ErrElementAborted)
+	s = progress.Snapshot()
+	assert.Equal(t, 2, s.Completed, "an aborted element should not be completed")
+	assert.Equal(t, 1, s.Aborted, "1 element should be aborted")
+	assert.Equal(t, 1, s.InFlight, "1 element should be in flight")
+
+	s = ProgressSnapshot{Completed: 10, Failed: 2, Aborted: 1, InFlight: 3, Throughput: 12.34}
+	assert.Equal(t, "10 completed (2 failed), 1 aborted, 3 in flight, 12.3/s", s.String())
+}
@@ -0,0 +1,61 @@
+package lists
+
+import "time"
+
+// Debug enables the debug mode of Options.Debug for every call, including the async methods which take no Options.
+var Debug = false
+
+// DebugGrace is how long async methods in debug mode wait for a payload once every go routine returned,
+// before reporting the ones which returned without writing to the chan, 0 means they are not reported.
+// It is a heuristic: a go routine writing to the chan from a go routine of its own is reported
+// if its write takes longer than DebugGrace, even though it is correct.
+var DebugGrace time.Duration
+
+// Options configures the async methods taking a context.
+// The zero value runs every element concurrently.
+type Options struct {
+	// MaxConcurrency sets the max number of go routines awaited at the same time, 0 means no limit.
+	MaxConcurrency int
+	// Pool runs the go routines, if nil a Pool is started for the call and closed when it returns.
+	Pool *Pool
+	// RateLimit limits the rate at which go routines are started, if nil they are started as soon as the concurrency allows.
+	RateLimit *Limiter
+	// ElementTimeout bounds the time given to each element from its start, 0 means no limit.
+	// An element which timed out is reported as ErrElementTimeout, or set to TimeoutFallback, without waiting for its go routine
+	// and the context given to it is cancelled. Its go routine should return once it is done,
+	// it is left running on its own otherwise, without holding a worker of the Pool.
+	ElementTimeout time.Duration
+	// TimeoutFallback is the value of the elements which timed out, if nil they are reported as ErrElementTimeout.
+	// It must be of the type of the values of the method, otherwise it is ignored.
+	TimeoutFallback interface{}
+	// Observer is notified of the start and the end of every element and of the end of the call, if not nil.
+	Observer Observer
+	// ErrorMode sets how errors are collected by the MapAsyncErr methods, defaults to FirstError.
+	ErrorMode ErrorMode
+	// Retry sets how the MapAsyncErr and MapAsyncRetry methods retry an element which failed, if nil it is not retried.
+	Retry *RetryPolicy
+	// OnPanic sets what happens when a go routine panics, defaults to PanicPropagate.
+	OnPanic PanicMode
+	// Ordered makes MapAsyncStream methods of slices yield results in the order of the elements,
+	// results completed before the ones of previous elements are held until those are yielded.
+	Ordered bool
+	// Debug makes MapAsync methods report misuses of the chan which would otherwise go unnoticed:
+	// payloads written twice for the same element, and with DebugGrace go routines returning without writing to the chan.
+	// Malformed payloads and indexes out of range are reported with or without Debug.
+	// They are returned as a *ElementError whose Err is a *ProtocolError,
+	// methods returning no error panic with that *ElementError instead.
+	Debug bool
+}
+
+// PanicMode sets what happens when a go routine started by an async method panics.
+type PanicMode int
+
+const (
+	// PanicPropagate does not recover panics, they crash the program as in a bare go routine.
+	PanicPropagate PanicMode = iota
+	// PanicContinue recovers panics as a *PanicError and keeps running the remaining elements.
+	// All the recovered panics are returned joined once every element is done.
+	PanicContinue
+	// PanicAbort recovers panics as a *PanicError, stops starting go routines and returns the first one.
+	PanicAbort
+)
@@ -0,0 +1,242 @@
+// Package pipeline chains stages processing the elements of a list one at a time, each stage running in its own go routines.
+//
+// Stages are connected by unbuffered chans, a stage only reads an element once the next stage has taken the previous one,
+// so that no intermediate list is materialized and a slow stage slows down the ones before it.
+// A pipeline starts with a source (From, FromChan), goes through stages (Map, Filter, FlatMap, Batch, MapAsync)
+// and runs when a sink (ToSlice, ToAnySlice, ToMap, ForEach) reads it:
+//
+//	words := pipeline.From(ctx, lines)
+//	words = pipeline.FlatMap(words, strings.Fields)
+//	lens := pipeline.MapAsync(words, lookup, 10)
+//	result, err := pipeline.ToSlice(lens)
+//
+// The first error returned by a stage cancels the pipeline and is returned by the sink.
+// A pipeline can be read by one sink only.
+package pipeline
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/francoispqt/lists/chans"
+	"github.com/francoispqt/lists/maps"
+	"github.com/francoispqt/lists/slices"
+)
+
+// Pipeline is a chain of stages yielding elements of type T.
+type Pipeline[T any] struct {
+	run *run
+	out <-chan T
+}
+
+// run is the state shared by the stages of a pipeline.
+type run struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	mu     sync.Mutex
+	err    error
+}
+
+func newRun(ctx context.Context) *run {
+	r := &run{}
+	r.ctx, r.cancel = context.WithCancel(ctx)
+	return r
+}
+
+// fail cancels the pipeline, err is kept if it is the first error.
+func (r *run) fail(err error) {
+	r.mu.Lock()
+	if r.err == nil {
+		r.err = err
+	}
+	r.mu.Unlock()
+	r.cancel()
+}
+
+// done cancels the pipeline and returns its first error, or the error of the parent context.
+func (r *run) done() error {
+	r.mu.Lock()
+	err := r.err
+	r.mu.Unlock()
+	if err == nil {
+		err = r.ctx.Err()
+	}
+	r.cancel()
+	return err
+}
+
+func send[T any](ctx context.Context, out chan<- T, v T) bool {
+	select {
+	case out <- v:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// From returns a pipeline yielding the elements of s in order.
+func From[T any](ctx context.Context, s []T) Pipeline[T] {
+	r := newRun(ctx)
+	return Pipeline[T]{run: r, out: slices.AnySlice[T](s).ToChan(r.ctx, 0)}
+}
+
+// FromChan returns a pipeline yielding the values of ch until it is closed.
+func FromChan[T any](ctx context.Context, ch <-chan T) Pipeline[T] {
+	r := newRun(ctx)
+	return Pipeline[T]{run: r, out: chans.OrDone(r.ctx, ch)}
+}
+
+// stage starts a go routine calling fn for every element of p, fn returns false to stop the stage.
+func stage[T any, U any](p Pipeline[T], fn func(ctx context.Context, v T, out chan<- U) bool) Pipeline[U] {
+	out := make(chan U)
+	go func() {
+		defer close(out)
+		for {
+			select {
+			case v, ok := <-p.out:
+				if !ok || !fn(p.run.ctx, v, out) {
+					return
+				}
+			case <-p.run.ctx.Done():
+				return
+			}
+		}
+	}()
+	return Pipeline[U]{run: p.run, out: out}
+}
+
+// Map returns a pipeline yielding the results of fn called on every element of p.
+func Map[T any, U any](p Pipeline[T], fn func(T) U) Pipeline[U] {
+	return stage(p, func(ctx context.Context, v T, out chan<- U) bool {
+		return send(ctx, out, fn(v))
+	})
+}
+
+// Filter returns a pipeline yielding the elements of p for which fn returns true.
+func Filter[T any](p Pipeline[T], fn func(T) bool) Pipeline[T] {
+	return stage(p, func(ctx context.Context, v T, out chan<- T) bool {
+		return !fn(v) || send(ctx, out, v)
+	})
+}
+
+// FlatMap returns a pipeline yielding, in order, every element of the slices returned by fn called on every element of p.
+func FlatMap[T any, U any](p Pipeline[T], fn func(T) []U) Pipeline[U] {
+	return stage(p, func(ctx context.Context, v T, out chan<- U) bool {
+		for _, u := range fn(v) {
+			if !send(ctx, out, u) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// Batch returns a pipeline yielding the elements of p grouped in slices of size elements,
+// a batch being yielded before it is full once maxWait has elapsed since its first element, see chans.Batch.
+func Batch[T any](p Pipeline[T], size int, maxWait time.Duration) Pipeline[[]T] {
+	return Pipeline[[]T]{run: p.run, out: chans.Batch(p.run.ctx, p.out, size, maxWait)}
+}
+
+// MapAsync returns a pipeline yielding the results of fn called on every element of p by up to workers go routines.
+// Results are yielded in the order of the elements, no more than workers elements are processed at the same time.
+// If fn returns an error, the pipeline is canceled and the error is returned by the sink.
+// If workers is not higher than 0, runtime.GOMAXPROCS(0) workers are used.
+func MapAsync[T any, U any](p Pipeline[T], fn func(context.Context, T) (U, error), workers int) Pipeline[U] {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	type result struct {
+		value U
+		err   error
+	}
+	ctx := p.run.ctx
+	// pending holds the result chans of the elements in flight, in the order of the elements,
+	// with the one the emitting go routine waits for they are never more than workers
+	pending := make(chan chan result, workers-1)
+	go func() {
+		defer close(pending)
+		for {
+			var v T
+			var ok bool
+			select {
+			case v, ok = <-p.out:
+			case <-ctx.Done():
+			}
+			if !ok {
+				return
+			}
+			res := make(chan result, 1)
+			if !send(ctx, pending, res) {
+				return
+			}
+			go func() {
+				u, err := fn(ctx, v)
+				res <- result{u, err}
+			}()
+		}
+	}()
+
+	out := make(chan U)
+	go func() {
+		defer close(out)
+		for res := range pending {
+			if ctx.Err() != nil {
+				// drain pending until the dispatching go routine closes it
+				continue
+			}
+			select {
+			case r := <-res:
+				if r.err != nil {
+					p.run.fail(r.err)
+					continue
+				}
+				send(ctx, out, r.value)
+			case <-ctx.Done():
+			}
+		}
+	}()
+	return Pipeline[U]{run: p.run, out: out}
+}
+
+// ForEach calls fn on every element of p, in order, and returns the first error of the pipeline or of fn.
+// If the context of the pipeline is done, its error is returned.
+func ForEach[T any](p Pipeline[T], fn func(T) error) error {
+	for v := range p.out {
+		if err := fn(v); err != nil {
+			p.run.fail(err)
+			break
+		}
+	}
+	return p.run.done()
+}
+
+// ToAnySlice runs the pipeline and returns its elements, with the first error of the pipeline.
+func ToAnySlice[T any](p Pipeline[T]) (slices.AnySlice[T], error) {
+	var ret = make(slices.AnySlice[T], 0)
+	err := ForEach(p, func(v T) error {
+		ret = append(ret, v)
+		return nil
+	})
+	return ret, err
+}
+
+// ToSlice runs the pipeline and returns its elements, with the first error of the pipeline.
+// The result can be converted to the legacy slice types, as in slices.IntSlice(result).
+func ToSlice[T comparable](p Pipeline[T]) (slices.Slice[T], error) {
+	ret, err := ToAnySlice(p)
+	return slices.Slice[T](ret), err
+}
+
+// ToMap runs the pipeline and returns a map of the keys and values returned by fn for each element,
+// with the first error of the pipeline. An element with the same key as a previous one overwrites it.
+func ToMap[T any, K comparable, V any](p Pipeline[T], fn func(T) (K, V)) (maps.Map[K, V], error) {
+	var ret = make(maps.Map[K, V])
+	err := ForEach(p, func(v T) error {
+		k, val := fn(v)
+		ret[k] = val
+		return nil
+	})
+	return ret, err
+}
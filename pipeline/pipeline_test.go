@@ -0,0 +1,152 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/francoispqt/lists/maps"
+	"github.com/francoispqt/lists/slices"
+	"github.com/stretchr/testify/assert"
+)
+
+// noLeak fails the test if the number of go routines does not go back to n.
+func noLeak(t *testing.T, n int) {
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > n {
+		if time.Now().After(deadline) {
+			t.Fatalf("go routines leaked: %d running, %d before", runtime.NumGoroutine(), n)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestPipeline(t *testing.T) {
+	ctx := context.Background()
+	n := runtime.NumGoroutine()
+
+	words := FlatMap(From(ctx, []string{"hello world", "foo bar baz"}), strings.Fields)
+	long := Filter(words, func(w string) bool {
+		return len(w) > 3
+	})
+	upper := Map(long, strings.ToUpper)
+	result, err := ToSlice(upper)
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, slices.Slice[string]{"HELLO", "WORLD"}, result, "the stages should be applied in order")
+
+	lens, err := ToMap(From(ctx, []string{"a", "bb", "ccc"}), func(s string) (string, int) {
+		return s, len(s)
+	})
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, maps.Map[string, int]{"a": 1, "bb": 2, "ccc": 3}, lens, "ToMap should map every element")
+
+	batches, err := ToAnySlice(Batch(From(ctx, []int{1, 2, 3, 4, 5}), 2, 0))
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, slices.AnySlice[[]int]{{1, 2}, {3, 4}, {5}}, batches, "Batch should group elements by 2")
+
+	ch := make(chan int, 3)
+	ch <- 1
+	ch <- 2
+	ch <- 3
+	close(ch)
+	ints, err := ToSlice(Map(FromChan(ctx, ch), func(v int) int { return v * 2 }))
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, slices.IntSlice{2, 4, 6}, slices.IntSlice(ints), "FromChan should read the chan")
+
+	noLeak(t, n)
+}
+
+func TestPipelineMapAsync(t *testing.T) {
+	ctx := context.Background()
+	n := runtime.NumGoroutine()
+
+	var inFlight, maxInFlight int32
+	var input = make([]int, 50)
+	for i := range input {
+		input[i] = i
+	}
+	strs := MapAsync(From(ctx, input), func(ctx context.Context, v int) (string, error) {
+		cur := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if cur <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, cur) {
+				break
+			}
+		}
+		// later elements finish first
+		time.Sleep(time.Duration(50-v) * 100 * time.Microsecond)
+		return strconv.Itoa(v), nil
+	}, 4)
+	result, err := ToSlice(strs)
+	assert.Nil(t, err, "err should be nil")
+	assert.Len(t, result, 50, "every element should be mapped")
+	for i, v := range result {
+		assert.Equal(t, strconv.Itoa(i), v, "results should be in the order of the elements")
+	}
+	assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(4), "no more than 4 elements should be processed at the same time")
+
+	// the first error cancels the pipeline
+	testErr := errors.New("test")
+	var calls int32
+	_, err = ToSlice(MapAsync(From(ctx, input), func(ctx context.Context, v int) (int, error) {
+		atomic.AddInt32(&calls, 1)
+		if v == 5 {
+			return 0, testErr
+		}
+		return v, nil
+	}, 2))
+	assert.Equal(t, testErr, err, "err should be the error of the stage")
+	assert.Less(t, atomic.LoadInt32(&calls), int32(50), "elements after the error should not be processed")
+
+	// ForEach errors cancel the pipeline too
+	var seen int
+	err = ForEach(From(ctx, input), func(v int) error {
+		seen++
+		if v == 2 {
+			return testErr
+		}
+		return nil
+	})
+	assert.Equal(t, testErr, err, "err should be the error of the func")
+	assert.Equal(t, 3, seen, "no element should be read after the error")
+
+	noLeak(t, n)
+}
+
+func TestPipelineCancel(t *testing.T) {
+	n := runtime.NumGoroutine()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var read int32
+	ch := make(chan int)
+	go func() {
+		// back-pressure: the source is only read as fast as the sink
+		for i := 0; ; i++ {
+			select {
+			case ch <- i:
+				atomic.AddInt32(&read, 1)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	p := MapAsync(Map(FromChan(ctx, ch), func(v int) int { return v }), func(ctx context.Context, v int) (int, error) {
+		return v, nil
+	}, 2)
+	err := ForEach(p, func(v int) error {
+		if v == 10 {
+			cancel()
+		}
+		return nil
+	})
+	assert.Equal(t, context.Canceled, err, "err should be the error of the context")
+	assert.Less(t, atomic.LoadInt32(&read), int32(20), "the source should not be read ahead of the sink")
+
+	noLeak(t, n)
+}
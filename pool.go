@@ -0,0 +1,137 @@
+package lists
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+)
+
+// ErrPoolClosed is returned when submitting a task to a closed Pool.
+var ErrPoolClosed = errors.New("lists: pool is closed")
+
+// Pool is a fixed number of workers running tasks from a bounded queue.
+// Async methods run their go routines in the Pool given in Options.Pool,
+// a Pool shared by several calls bounds their concurrency as a whole.
+// Tasks must not wait for other tasks of the same Pool, it could deadlock once all workers are busy.
+type Pool struct {
+	tasks chan func()
+	quit  chan struct{}
+
+	mu         sync.Mutex
+	cond       *sync.Cond
+	closed     bool
+	pending    int
+	submitting sync.WaitGroup
+	workers    sync.WaitGroup
+}
+
+// NewPool starts a Pool of workers go routines with a queue of queueSize tasks.
+// If workers is lower than 1, runtime.NumCPU() workers are started.
+// With a queueSize of 0, Submit blocks until a worker is available.
+func NewPool(workers, queueSize int) *Pool {
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+	if queueSize < 0 {
+		queueSize = 0
+	}
+	p := &Pool{
+		tasks: make(chan func(), queueSize),
+		quit:  make(chan struct{}),
+	}
+	p.cond = sync.NewCond(&p.mu)
+	p.workers.Add(workers)
+	for i := 0; i < workers; i++ {
+		go p.work()
+	}
+	return p
+}
+
+func (p *Pool) work() {
+	defer p.workers.Done()
+	for task := range p.tasks {
+		p.run(task)
+	}
+}
+
+func (p *Pool) run(task func()) {
+	defer p.done()
+	task()
+}
+
+func (p *Pool) done() {
+	p.mu.Lock()
+	p.pending--
+	if p.pending == 0 {
+		p.cond.Broadcast()
+	}
+	p.mu.Unlock()
+}
+
+// Submit queues a task, blocking while the queue is full.
+// Returns ctx.Err() if the context is done before the task is queued, or ErrPoolClosed if the Pool is closed.
+func (p *Pool) Submit(ctx context.Context, task func()) error {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return ErrPoolClosed
+	}
+	p.pending++
+	p.submitting.Add(1)
+	p.mu.Unlock()
+	defer p.submitting.Done()
+
+	select {
+	case p.tasks <- task:
+		return nil
+	case <-ctx.Done():
+		p.done()
+		return ctx.Err()
+	case <-p.quit:
+		p.done()
+		return ErrPoolClosed
+	}
+}
+
+// Wait blocks until every task submitted is done.
+func (p *Pool) Wait() {
+	p.mu.Lock()
+	for p.pending > 0 {
+		p.cond.Wait()
+	}
+	p.mu.Unlock()
+}
+
+// Close stops accepting tasks, tasks already queued are still run.
+// Close does not wait for them, see Shutdown.
+func (p *Pool) Close() {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.closed = true
+	close(p.quit)
+	p.mu.Unlock()
+
+	p.submitting.Wait()
+	close(p.tasks)
+}
+
+// Shutdown closes the Pool and waits for the queued tasks to be done and the workers to exit.
+// Returns ctx.Err() if the context is done before.
+func (p *Pool) Shutdown(ctx context.Context) error {
+	p.Close()
+	exited := make(chan struct{})
+	go func() {
+		p.workers.Wait()
+		close(exited)
+	}()
+	select {
+	case <-exited:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
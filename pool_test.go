@@ -0,0 +1,74 @@
+package lists
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPool(t *testing.T) {
+	pool := NewPool(3, 10)
+
+	var running, max, done int32
+	for i := 0; i < 30; i++ {
+		err := pool.Submit(context.Background(), func() {
+			n := atomic.AddInt32(&running, 1)
+			for {
+				m := atomic.LoadInt32(&max)
+				if n <= m || atomic.CompareAndSwapInt32(&max, m, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			atomic.AddInt32(&done, 1)
+		})
+		assert.Nil(t, err, "err should be nil")
+	}
+	pool.Wait()
+
+	assert.Equal(t, int32(30), atomic.LoadInt32(&done), "every task should be done after Wait")
+	assert.True(t, atomic.LoadInt32(&max) <= 3, "no more than 3 tasks should run at the same time")
+
+	assert.Nil(t, pool.Shutdown(context.Background()), "shutdown should succeed")
+	assert.Equal(t, ErrPoolClosed, pool.Submit(context.Background(), func() {}), "submit should fail once closed")
+	pool.Close()
+}
+
+func TestPoolSubmitContext(t *testing.T) {
+	pool := NewPool(1, 0)
+	defer pool.Close()
+
+	release := make(chan struct{})
+	assert.Nil(t, pool.Submit(context.Background(), func() { <-release }), "err should be nil")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, pool.Submit(ctx, func() {}), "submit should fail when no worker is available before the deadline")
+
+	close(release)
+	pool.Wait()
+}
+
+func TestPoolShutdown(t *testing.T) {
+	pool := NewPool(0, 5)
+
+	var done int32
+	for i := 0; i < 5; i++ {
+		pool.Submit(context.Background(), func() {
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&done, 1)
+		})
+	}
+	assert.Nil(t, pool.Shutdown(context.Background()), "shutdown should succeed")
+	assert.Equal(t, int32(5), atomic.LoadInt32(&done), "queued tasks should be done after shutdown")
+
+	pool = NewPool(1, 0)
+	pool.Submit(context.Background(), func() { time.Sleep(50 * time.Millisecond) })
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, pool.Shutdown(ctx), "shutdown should return when the context is done")
+}
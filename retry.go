@@ -0,0 +1,80 @@
+package lists
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy sets how the MapAsyncErr and MapAsyncRetry methods retry an element which failed.
+// The wait before the second attempt is Backoff, it is multiplied by Multiplier after each attempt up to MaxBackoff.
+// A nil RetryPolicy never retries.
+type RetryPolicy struct {
+	// MaxAttempts is the max number of times the func is called for an element, including the first one.
+	MaxAttempts int
+	// Backoff is the wait before the second attempt.
+	Backoff time.Duration
+	// MaxBackoff caps the wait between two attempts, 0 means no cap.
+	MaxBackoff time.Duration
+	// Multiplier multiplies the wait after each attempt, defaults to 2.
+	Multiplier float64
+	// Jitter randomizes the wait by up to this fraction of it, between 0 and 1: a wait d becomes a random one in [d*(1-Jitter), d].
+	Jitter float64
+	// Retryable reports whether an error must be retried, if nil every error is.
+	// It is given the error returned by the func, not the *ElementError wrapping it.
+	// Recovered panics and errors returned once the context is done are never retried.
+	Retryable func(error) bool
+	// Clock is used to wait between attempts, if nil the system clock is.
+	Clock Clock
+}
+
+// Delay returns the wait after the given failed attempt, from 1, before jitter.
+func (p *RetryPolicy) Delay(attempt int) time.Duration {
+	multiplier := p.Multiplier
+	if multiplier <= 0 {
+		multiplier = 2
+	}
+	d := float64(p.Backoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		return p.MaxBackoff
+	}
+	return time.Duration(d)
+}
+
+// Retry reports whether an element must be called again after the given attempt failed with err,
+// waiting for the backoff before returning true. It returns false if the context is done while waiting.
+func (p *RetryPolicy) Retry(ctx context.Context, attempt int, err error) bool {
+	if p == nil || attempt >= p.MaxAttempts || ctx.Err() != nil {
+		return false
+	}
+	var panicErr *PanicError
+	if errors.As(err, &panicErr) {
+		return false
+	}
+	if p.Retryable != nil {
+		var elemErr *ElementError
+		if errors.As(err, &elemErr) {
+			err = elemErr.Err
+		}
+		if !p.Retryable(err) {
+			return false
+		}
+	}
+
+	d := p.Delay(attempt)
+	if p.Jitter > 0 {
+		d -= time.Duration(rand.Float64() * math.Min(p.Jitter, 1) * float64(d))
+	}
+	var clock = p.Clock
+	if clock == nil {
+		clock = systemClock{}
+	}
+	select {
+	case <-clock.After(d):
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
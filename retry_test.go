@@ -0,0 +1,63 @@
+package lists
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := &RetryPolicy{Backoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	assert.Equal(t, 100*time.Millisecond, policy.Delay(1), "first wait should be Backoff")
+	assert.Equal(t, 200*time.Millisecond, policy.Delay(2), "wait should double by default")
+	assert.Equal(t, 800*time.Millisecond, policy.Delay(4), "wait should double by default")
+	assert.Equal(t, time.Second, policy.Delay(5), "wait should be capped to MaxBackoff")
+
+	policy.Multiplier = 3
+	assert.Equal(t, 900*time.Millisecond, policy.Delay(3), "wait should be multiplied by Multiplier")
+}
+
+func TestRetryPolicyRetry(t *testing.T) {
+	errBoom := errors.New("boom")
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	policy := &RetryPolicy{MaxAttempts: 3, Backoff: time.Second, Jitter: 0.5, Clock: clock}
+
+	done := make(chan bool)
+	go func() {
+		done <- policy.Retry(context.Background(), 1, errBoom)
+	}()
+	select {
+	case <-done:
+		t.Fatal("Retry should wait for the backoff")
+	case <-time.After(20 * time.Millisecond):
+	}
+	// with a jitter of 0.5, the wait is between 500ms and 1s
+	clock.Advance(time.Second)
+	select {
+	case retry := <-done:
+		assert.True(t, retry, "element should be retried")
+	case <-time.After(time.Second):
+		t.Fatal("Retry should return once the backoff elapsed")
+	}
+
+	assert.False(t, policy.Retry(context.Background(), 3, errBoom), "element should not be retried after MaxAttempts")
+	assert.False(t, (*RetryPolicy)(nil).Retry(context.Background(), 1, errBoom), "a nil policy should never retry")
+	assert.False(t, policy.Retry(context.Background(), 1, &ElementError{Key: 1, Err: &PanicError{Value: "boom"}}), "panics should not be retried")
+
+	policy.Retryable = func(err error) bool {
+		return !errors.Is(err, errBoom)
+	}
+	assert.False(t, policy.Retry(context.Background(), 1, errBoom), "errors not retryable should not be retried")
+
+	policy.Retryable = func(err error) bool {
+		return err != errBoom
+	}
+	assert.False(t, policy.Retry(context.Background(), 1, &ElementError{Key: 1, Err: errBoom}), "Retryable should be given the error unwrapped from the element error")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.False(t, policy.Retry(ctx, 1, errors.New("other")), "element should not be retried once the context is done")
+}
@@ -0,0 +1,23 @@
+//go:build go1.23
+
+package sets
+
+import (
+	"github.com/francoispqt/lists/iters"
+)
+
+// Values method returns a lazy iters.Iter yielding the elements of the set, in no particular order.
+func (s Set[T]) Values() iters.Iter[T] {
+	return func(yield func(T) bool) {
+		for v := range s {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Values method returns a lazy iters.Iter yielding the elements of a Snapshot of the set, in no particular order.
+func (s *SyncSet[T]) Values() iters.Iter[T] {
+	return s.Snapshot().Values()
+}
@@ -0,0 +1,24 @@
+//go:build go1.23
+
+package sets
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIter(t *testing.T) {
+	var ints []int
+	for v := range New(1, 2, 3).Values() {
+		ints = append(ints, v)
+	}
+	sort.Ints(ints)
+	assert.Equal(t, []int{1, 2, 3}, ints, "Values should yield every element")
+
+	assert.Len(t, New(1, 2, 3).Values().Take(2).Collect(), 2, "Take should stop the iteration")
+	assert.Equal(t, []int{2}, NewSync(1, 2).Values().Filter(func(v int) bool {
+		return v > 1
+	}).Collect(), "SyncSet values should be filtered")
+}
@@ -0,0 +1,134 @@
+// Package sets provides Set, a generic set of comparable elements, and SyncSet, its variant safe for concurrent use.
+package sets
+
+import (
+	"github.com/francoispqt/lists/slices"
+)
+
+// Set is a generic set of comparable elements. Its zero value is a nil map, use New or make(Set[T]) to add elements.
+// The order of the elements is not specified.
+type Set[T comparable] map[T]struct{}
+
+// New returns a Set of the given values.
+func New[T comparable](values ...T) Set[T] {
+	var s = make(Set[T], len(values))
+	s.Add(values...)
+	return s
+}
+
+// FromSlice returns a Set of the elements of c, any slice type of the slices package can be given.
+func FromSlice[T comparable](c []T) Set[T] {
+	return New(c...)
+}
+
+// FromMapKeys returns a Set of the keys of m, any map type of the maps package can be given.
+func FromMapKeys[K comparable, V any](m map[K]V) Set[K] {
+	var s = make(Set[K], len(m))
+	for k := range m {
+		s[k] = struct{}{}
+	}
+	return s
+}
+
+// Add method adds the values to the set.
+func (s Set[T]) Add(values ...T) {
+	for _, v := range values {
+		s[v] = struct{}{}
+	}
+}
+
+// Remove method removes the values from the set.
+func (s Set[T]) Remove(values ...T) {
+	for _, v := range values {
+		delete(s, v)
+	}
+}
+
+// Has method determines whether the set includes a certain element.
+func (s Set[T]) Has(v T) bool {
+	_, ok := s[v]
+	return ok
+}
+
+// Len method returns the number of elements of the set.
+func (s Set[T]) Len() int {
+	return len(s)
+}
+
+// ForEach method executes a provided func once for each element of the set.
+func (s Set[T]) ForEach(cb func(T)) {
+	for v := range s {
+		cb(v)
+	}
+}
+
+// Union method creates a new set with the elements of s and the ones of o.
+func (s Set[T]) Union(o Set[T]) Set[T] {
+	var ret = make(Set[T], len(s)+len(o))
+	for v := range s {
+		ret[v] = struct{}{}
+	}
+	for v := range o {
+		ret[v] = struct{}{}
+	}
+	return ret
+}
+
+// Intersect method creates a new set with the elements of s which are in o.
+func (s Set[T]) Intersect(o Set[T]) Set[T] {
+	small, large := s, o
+	if len(large) < len(small) {
+		small, large = large, small
+	}
+	var ret = make(Set[T])
+	for v := range small {
+		if large.Has(v) {
+			ret[v] = struct{}{}
+		}
+	}
+	return ret
+}
+
+// Difference method creates a new set with the elements of s which are not in o.
+func (s Set[T]) Difference(o Set[T]) Set[T] {
+	var ret = make(Set[T])
+	for v := range s {
+		if !o.Has(v) {
+			ret[v] = struct{}{}
+		}
+	}
+	return ret
+}
+
+// IsSubset method reports whether every element of s is in o.
+func (s Set[T]) IsSubset(o Set[T]) bool {
+	if len(s) > len(o) {
+		return false
+	}
+	for v := range s {
+		if !o.Has(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal method reports whether s and o have the same elements.
+func (s Set[T]) Equal(o Set[T]) bool {
+	return len(s) == len(o) && s.IsSubset(o)
+}
+
+// Clone method creates a new set with the elements of s.
+func (s Set[T]) Clone() Set[T] {
+	return s.Union(nil)
+}
+
+// Slice method creates a new slices.Slice with the elements of the set, in no particular order.
+// For a sorted slice of ordered elements, see slices.Sort.
+func (s Set[T]) Slice() slices.Slice[T] {
+	var ret = make(slices.Slice[T], 0, len(s))
+	for v := range s {
+		ret = append(ret, v)
+	}
+	return ret
+}
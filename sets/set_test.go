@@ -0,0 +1,64 @@
+package sets
+
+import (
+	"testing"
+
+	"github.com/francoispqt/lists/maps"
+	"github.com/francoispqt/lists/slices"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSet(t *testing.T) {
+	s := New(1, 2, 3)
+	assert.Equal(t, 3, s.Len(), "len should be 3")
+	assert.True(t, s.Has(2), "s should have 2")
+	assert.False(t, s.Has(4), "s should not have 4")
+
+	s.Add(4, 4)
+	assert.Equal(t, 4, s.Len(), "4 should be added once")
+	s.Remove(1, 5)
+	assert.False(t, s.Has(1), "1 should be removed")
+	assert.Equal(t, New(2, 3, 4), s, "s should be 2, 3, 4")
+
+	a, b := New(1, 2, 3), New(2, 3, 4)
+	assert.Equal(t, New(1, 2, 3, 4), a.Union(b), "union should have the elements of both sets")
+	assert.Equal(t, New(2, 3), a.Intersect(b), "intersect should have the common elements")
+	assert.Equal(t, New(1), a.Difference(b), "difference should have the elements of a not in b")
+	assert.Equal(t, New(1, 2, 3), a, "a should be left unchanged")
+
+	assert.True(t, New(2, 3).IsSubset(a), "2, 3 should be a subset of a")
+	assert.True(t, New[int]().IsSubset(a), "the empty set should be a subset of a")
+	assert.False(t, b.IsSubset(a), "b should not be a subset of a")
+	assert.True(t, a.Equal(New(3, 2, 1)), "a should equal 3, 2, 1")
+	assert.False(t, a.Equal(b), "a should not equal b")
+	assert.False(t, a.Equal(New(1, 2)), "a should not equal a subset")
+
+	sum := 0
+	a.ForEach(func(v int) {
+		sum += v
+	})
+	assert.Equal(t, 6, sum, "foreach should visit every element")
+
+	clone := a.Clone()
+	clone.Add(10)
+	assert.False(t, a.Has(10), "the clone should be a copy")
+
+	var nilSet Set[int]
+	assert.False(t, nilSet.Has(1), "nil set should have nothing")
+	assert.Equal(t, 0, nilSet.Len(), "nil set len should be 0")
+	assert.Equal(t, New(1), nilSet.Union(New(1)), "union with a nil set should work")
+}
+
+func TestSetConversions(t *testing.T) {
+	s := FromSlice(slices.StringSlice{"foo", "bar", "foo"})
+	assert.Equal(t, New("foo", "bar"), s, "set should have the elements of the slice")
+	assert.Equal(t, New(1, 2), FromSlice(slices.Slice[int]{1, 2}), "set should have the elements of the generic slice")
+
+	sorted := slices.Sort(s.Slice())
+	assert.Equal(t, slices.Slice[string]{"bar", "foo"}, sorted, "slice should have the elements of the set")
+
+	m := maps.MapStringInt{"a": 1, "b": 2}
+	assert.Equal(t, New("a", "b"), FromMapKeys(m), "set should have the keys of the map")
+	assert.Equal(t, New("a", "b"), New(m.Indexes()...), "set should have the indexes of the map")
+	assert.Equal(t, New(1), FromMapKeys(maps.Map[int, string]{1: "a"}), "set should have the keys of the generic map")
+}
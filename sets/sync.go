@@ -0,0 +1,123 @@
+package sets
+
+import (
+	"sync"
+
+	"github.com/francoispqt/lists/slices"
+)
+
+// SyncSet is a Set safe for concurrent use, its methods lock a mutex.
+// Operations with another SyncSet take a Snapshot of it, as in a.Union(b.Snapshot()),
+// so that no two SyncSets are ever locked at the same time.
+// The zero value is an empty SyncSet ready to use, a SyncSet must not be copied after first use.
+type SyncSet[T comparable] struct {
+	mu sync.RWMutex
+	s  Set[T]
+}
+
+// NewSync returns a SyncSet of the given values.
+func NewSync[T comparable](values ...T) *SyncSet[T] {
+	return &SyncSet[T]{s: New(values...)}
+}
+
+// Add method adds the values to the set.
+func (s *SyncSet[T]) Add(values ...T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.s == nil {
+		s.s = make(Set[T], len(values))
+	}
+	s.s.Add(values...)
+}
+
+// AddIfAbsent method adds v to the set if it is not in it, it reports whether v was added.
+func (s *SyncSet[T]) AddIfAbsent(v T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.s.Has(v) {
+		return false
+	}
+	if s.s == nil {
+		s.s = make(Set[T])
+	}
+	s.s.Add(v)
+	return true
+}
+
+// Remove method removes the values from the set.
+func (s *SyncSet[T]) Remove(values ...T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.s.Remove(values...)
+}
+
+// Has method determines whether the set includes a certain element.
+func (s *SyncSet[T]) Has(v T) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.Has(v)
+}
+
+// Len method returns the number of elements of the set.
+func (s *SyncSet[T]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.s)
+}
+
+// ForEach method executes a provided func once for each element of the set.
+// The set is locked for reading meanwhile, cb must not modify it.
+func (s *SyncSet[T]) ForEach(cb func(T)) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	s.s.ForEach(cb)
+}
+
+// Snapshot method creates a new Set with the current elements of the set.
+func (s *SyncSet[T]) Snapshot() Set[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.Clone()
+}
+
+// Union method creates a new Set with the elements of s and the ones of o.
+func (s *SyncSet[T]) Union(o Set[T]) Set[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.Union(o)
+}
+
+// Intersect method creates a new Set with the elements of s which are in o.
+func (s *SyncSet[T]) Intersect(o Set[T]) Set[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.Intersect(o)
+}
+
+// Difference method creates a new Set with the elements of s which are not in o.
+func (s *SyncSet[T]) Difference(o Set[T]) Set[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.Difference(o)
+}
+
+// IsSubset method reports whether every element of s is in o.
+func (s *SyncSet[T]) IsSubset(o Set[T]) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.IsSubset(o)
+}
+
+// Equal method reports whether s and o have the same elements.
+func (s *SyncSet[T]) Equal(o Set[T]) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.Equal(o)
+}
+
+// Slice method creates a new slices.Slice with the elements of the set, in no particular order.
+func (s *SyncSet[T]) Slice() slices.Slice[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.Slice()
+}
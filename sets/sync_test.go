@@ -0,0 +1,57 @@
+package sets
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncSet(t *testing.T) {
+	var s SyncSet[int]
+	var added int32
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			s.Add(i)
+			if s.AddIfAbsent(i % 10) {
+				mu.Lock()
+				added++
+				mu.Unlock()
+			}
+			s.Has(i)
+			s.Len()
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, 100, s.Len(), "every element should be added")
+	assert.LessOrEqual(t, added, int32(10), "each of 0 to 9 should be added at most once by AddIfAbsent")
+
+	s.Remove(0, 1)
+	assert.False(t, s.Has(0), "0 should be removed")
+	assert.Equal(t, 98, s.Len(), "len should be 98")
+
+	a, b := NewSync(1, 2, 3), NewSync(2, 3, 4)
+	assert.Equal(t, New(1, 2, 3, 4), a.Union(b.Snapshot()), "union should have the elements of both sets")
+	assert.Equal(t, New(2, 3), a.Intersect(b.Snapshot()), "intersect should have the common elements")
+	assert.Equal(t, New(1), a.Difference(b.Snapshot()), "difference should have the elements of a not in b")
+	assert.True(t, a.IsSubset(New(1, 2, 3, 4)), "a should be a subset of 1, 2, 3, 4")
+	assert.True(t, a.Equal(New(1, 2, 3)), "a should equal 1, 2, 3")
+	assert.Len(t, a.Slice(), 3, "slice should have 3 elements")
+
+	snapshot := a.Snapshot()
+	a.Add(5)
+	assert.False(t, snapshot.Has(5), "the snapshot should be a copy")
+
+	sum := 0
+	a.ForEach(func(v int) {
+		sum += v
+	})
+	assert.Equal(t, 11, sum, "foreach should visit every element")
+
+	assert.True(t, a.AddIfAbsent(6), "6 should be added")
+	assert.False(t, a.AddIfAbsent(6), "6 should not be added twice")
+}
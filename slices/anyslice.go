@@ -0,0 +1,357 @@
+package slices
+
+import (
+	"context"
+
+	"github.com/francoispqt/lists"
+	"github.com/francoispqt/lists/internal/async"
+)
+
+// AnySlice is a generic custom type for a slice of any element type.
+// It offers the methods of Slice which do not need to compare elements.
+type AnySlice[T any] []T
+
+// ForEach method executes a provided func once for each slice element.
+func (c AnySlice[T]) ForEach(cb func(int, T)) {
+	for k, v := range c {
+		cb(k, v)
+	}
+}
+
+// Map method creates a new slice with the results of calling a provided func on every element in the calling array.
+// Returns a slice of the original type.
+// For asynchronicity, see MapAsync.
+func (c AnySlice[T]) Map(cb func(int, T) T) AnySlice[T] {
+	return MapTo(c, cb)
+}
+
+// MapInterface method creates a new slice with the results of calling a provided func on every element in the calling array.
+// Returns a slice of interfaces.
+// For asynchronicity, see MapAsyncInterface.
+func (c AnySlice[T]) MapInterface(cb func(int, T) interface{}) InterfaceSlice {
+	return MapTo(c, cb)
+}
+
+// MapAsync method creates a new slice with the results of calling a provided go routine on every element in the calling array.
+// The go routine must write a lists.Result to the chan, its Key being the index of the element.
+// An optional max concurrency can be passed as second argument, 0 means no limit.
+// Returns a slice of the original type.
+// If you want to map to a slice of different type, see MapAsyncInterface.
+func (c AnySlice[T]) MapAsync(cb func(int, T, chan<- lists.Result[int, T]), maxConcurrency ...int) AnySlice[T] {
+	return MapAsyncTo(c, cb, maxConcurrency...)
+}
+
+// MapAsyncContext method is MapAsync with a context given to every go routine.
+// When the context is done, no more go routine is started and MapAsyncContext returns ctx.Err() with the results received so far.
+func (c AnySlice[T]) MapAsyncContext(ctx context.Context, cb func(context.Context, int, T, chan<- lists.Result[int, T]), opts lists.Options) (AnySlice[T], error) {
+	return MapAsyncToContext(ctx, c, cb, opts)
+}
+
+// MapAsyncStream method calls a provided go routine on every element in the calling array and returns a chan yielding the results as soon as they are written.
+// With opts.Ordered, results are yielded in the order of the elements.
+// The chan is closed once every go routine is done, the error of the call (nil on success) is then written to the error chan.
+func (c AnySlice[T]) MapAsyncStream(ctx context.Context, cb func(context.Context, int, T, chan<- lists.Result[int, T]), opts lists.Options) (<-chan lists.Result[int, T], <-chan error) {
+	return MapAsyncStreamTo(ctx, c, cb, opts)
+}
+
+// MapAsyncErr method creates a new slice with the values returned by calling a provided func as a go routine on every element in the calling array.
+// Errors returned by the func are collected as set by opts.ErrorMode and wrapped in a *lists.ElementError, elements which failed are left to their zero value.
+// The context is given to every go routine, when it is done no more go routine is started.
+func (c AnySlice[T]) MapAsyncErr(ctx context.Context, cb func(context.Context, int, T) (T, error), opts lists.Options) (AnySlice[T], error) {
+	return MapAsyncErrTo(ctx, c, cb, opts)
+}
+
+// MapAsyncRetry method is MapAsyncErr returning the lists.Outcome of every element, with its number of attempts.
+// Failed elements are retried as set by opts.Retry.
+func (c AnySlice[T]) MapAsyncRetry(ctx context.Context, cb func(context.Context, int, T) (T, error), opts lists.Options) ([]lists.Outcome[T], error) {
+	return MapAsyncRetryTo(ctx, c, cb, opts)
+}
+
+// MapParallel method creates a new slice with the values returned by calling a provided func as a go routine on every element in the calling array.
+// No more than workers go routines run at the same time, 0 means no limit.
+// Returns a slice of the original type.
+func (c AnySlice[T]) MapParallel(cb func(int, T) T, workers int) AnySlice[T] {
+	return MapParallelTo(c, cb, workers)
+}
+
+// MapAsyncInterface method creates a new slice with the results of calling a provided go routine on every element in the calling array.
+// The go routine must write a lists.Result to the chan, its Key being the index of the element.
+// Returns InterfaceSlice.
+// If you know the result will be of original type, use MapAsync.
+func (c AnySlice[T]) MapAsyncInterface(cb func(int, T, chan<- lists.Result[int, interface{}]), maxConcurrency ...int) InterfaceSlice {
+	return MapAsyncTo(c, cb, maxConcurrency...)
+}
+
+// MapAsyncInterfaceContext method is MapAsyncInterface with a context given to every go routine.
+// When the context is done, no more go routine is started and MapAsyncInterfaceContext returns ctx.Err() with the results received so far.
+func (c AnySlice[T]) MapAsyncInterfaceContext(ctx context.Context, cb func(context.Context, int, T, chan<- lists.Result[int, interface{}]), opts lists.Options) (InterfaceSlice, error) {
+	return MapAsyncToContext(ctx, c, cb, opts)
+}
+
+// Reduce method applies a func against an accumulator and each element in the slice (from left to right) to reduce it to a single value of the original type.
+// To reduce to a value of any other type, see the Reduce func.
+// For asynchronicity, see ReduceAsync.
+func (c AnySlice[T]) Reduce(cb func(int, T, T) T, agg T) T {
+	return Reduce(c, cb, agg)
+}
+
+// ReduceAsync method applies a go routine against an accumulator and each element in the slice (from left to right) to reduce it to a single value of the original type.
+// You must read the aggregator from the lists.Aggregator.Agg channel before writing to the lists.Aggregator.Done channel.
+// To reduce to a value of any other type, see the ReduceAsync func.
+// For synchronicity, see Reduce.
+func (c AnySlice[T]) ReduceAsync(cb func(int, T, *lists.Aggregator[T]), agg T) T {
+	return ReduceAsync(c, cb, agg)
+}
+
+// ReduceAsyncContext method is ReduceAsync with a context given to every go routine.
+// When the context is done, no more go routine is started and the current state of the accumulator is returned with ctx.Err().
+// Panics are recovered as set by opts.OnPanic, the state of the accumulator is left unchanged by a go routine which panicked.
+func (c AnySlice[T]) ReduceAsyncContext(ctx context.Context, cb func(context.Context, int, T, *lists.Aggregator[T]), agg T, opts lists.Options) (T, error) {
+	return ReduceAsyncContext(ctx, c, cb, agg, opts)
+}
+
+// ReduceParallel method combines the elements of the slice to a single value of the original type, using workers go routines.
+// The slice is split into one chunk per worker, each chunk is reduced from identity, then the partial results are combined in a tree.
+// combine must be associative (combine(combine(a, b), c) == combine(a, combine(b, c))) and identity must leave any value unchanged when combined with it,
+// elements are always combined in their order so combine needs not be commutative.
+// If workers is not higher than 0, runtime.GOMAXPROCS(0) workers are used.
+// For a sequential reduction, see Reduce.
+func (c AnySlice[T]) ReduceParallel(identity T, combine func(T, T) T, workers int) T {
+	return async.ReduceParallel(c, identity, combine, workers)
+}
+
+// IsLast checks if the index passed is the last of the slice
+func (c AnySlice[T]) IsLast(i int) bool {
+	return i == len(c)-1
+}
+
+// Indexes returns a slice of ints with including the indexes of the slice
+func (c AnySlice[T]) Indexes() []int {
+	var indexes = make([]int, len(c))
+	for i := range c {
+		indexes[i] = i
+	}
+	return indexes
+}
+
+// Filter method creates a new slice with all elements that pass the test implemented by the provided function.
+func (c AnySlice[T]) Filter(cb func(k int, v T) bool) AnySlice[T] {
+	var ret = make([]T, 0)
+	for k, v := range c {
+		if cb(k, v) {
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
+
+// SortStable method creates a new slice with the elements sorted by the provided less func, equal elements keeping their order.
+// Returns a slice of the original type. For ordered element types, see the Sort and SortDesc funcs, to sort by a key, see SortBy.
+func (c AnySlice[T]) SortStable(less func(a, b T) bool) AnySlice[T] {
+	return SortStable(c, less)
+}
+
+// ToChan method returns a chan yielding the elements of the slice in order, with a buffer of the given size.
+// The chan is closed once every element is written or ctx is done.
+func (c AnySlice[T]) ToChan(ctx context.Context, buffer int) <-chan T {
+	out := make(chan T, buffer)
+	go func() {
+		defer close(out)
+		for _, v := range c {
+			if ctx.Err() != nil {
+				return
+			}
+			select {
+			case out <- v:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// Cast explicitly cast the AnySlice to a []T type
+func (c AnySlice[T]) Cast() []T {
+	return c
+}
+
+// FromChan func reads the values of ch until it is closed, or until limit values are read if limit is higher than 0.
+// Returns a []T, which can be assigned to any slice type: var s IntSlice = FromChan(ch, 0).
+// For the opposite, see the ToChan methods.
+func FromChan[T any](ch <-chan T, limit int) []T {
+	var ret = make([]T, 0)
+	for v := range ch {
+		ret = append(ret, v)
+		if limit > 0 && len(ret) == limit {
+			break
+		}
+	}
+	return ret
+}
+
+// MapTo func creates a new slice with the results of calling a provided func on every element in the calling array.
+// Returns a slice of the type returned by the func.
+// For asynchronicity, see MapAsyncTo.
+func MapTo[T any, U any](c []T, cb func(int, T) U) []U {
+	var ret = make([]U, len(c))
+	for k, v := range c {
+		ret[k] = cb(k, v)
+	}
+	return ret
+}
+
+// MapAsyncTo func creates a new slice with the results of calling a provided go routine on every element in the calling array.
+// The go routine must write a lists.Result to the chan, its Key being the index of the element.
+// An optional max concurrency can be passed as third argument, 0 means no limit.
+// Returns a slice of the type written to the chan.
+// For synchronicity, see MapTo.
+func MapAsyncTo[T any, U any](c []T, cb func(int, T, chan<- lists.Result[int, U]), maxConcurrency ...int) []U {
+	var ret = make([]U, len(c))
+	async.Map(async.Conc(maxConcurrency), resultJob(c, func(_ context.Context, i int, v T, mapChan chan<- lists.Result[int, U]) {
+		cb(i, v, mapChan)
+	}, ret))
+	return ret
+}
+
+// MapAsyncToContext func is MapAsyncTo with a context given to every go routine.
+// When the context is done, no more go routine is started and MapAsyncToContext returns ctx.Err() with the results received so far.
+func MapAsyncToContext[T any, U any](ctx context.Context, c []T, cb func(context.Context, int, T, chan<- lists.Result[int, U]), opts lists.Options) ([]U, error) {
+	var ret = make([]U, len(c))
+	err := async.MapContext(ctx, opts, resultJob(c, cb, ret))
+	return ret, err
+}
+
+// MapAsyncStreamTo func calls a provided go routine on every element in the calling array and returns a chan yielding the lists.Result written by each go routine as soon as it is written.
+// With opts.Ordered, results are yielded in the order of the elements, the ones completed early are held until the previous ones are yielded.
+// The chan is closed once every go routine is done, the error of the call (nil on success) is then written to the error chan.
+// When the context is done, no more go routine is started and results not yet read are dropped.
+// For a slice of the results, see MapAsyncToContext.
+func MapAsyncStreamTo[T any, U any](ctx context.Context, c []T, cb func(context.Context, int, T, chan<- lists.Result[int, U]), opts lists.Options) (<-chan lists.Result[int, U], <-chan error) {
+	return async.Stream(ctx, opts, resultJob(c, cb, nil))
+}
+
+// resultJob returns the job of the lists.Result protocol, storing the values to ret.
+func resultJob[T any, U any](c []T, cb func(context.Context, int, T, chan<- lists.Result[int, U]), ret []U) async.Job[lists.Result[int, U]] {
+	return async.Job[lists.Result[int, U]]{
+		N:   len(c),
+		Key: async.Index,
+		Launch: func(ctx context.Context, i int, mapChan chan lists.Result[int, U]) {
+			cb(ctx, i, c[i], mapChan)
+		},
+		Index: func(r lists.Result[int, U]) (int, error) {
+			return r.Key, nil
+		},
+		Store: func(i int, r lists.Result[int, U]) {
+			ret[i] = r.Value
+		},
+		Fallback: func(i int, v interface{}) (lists.Result[int, U], bool) {
+			u, ok := async.As[U](v)
+			return lists.Result[int, U]{Key: i, Value: u}, ok
+		},
+	}
+}
+
+// MapAsyncErrTo func creates a new slice with the values returned by calling a provided func as a go routine on every element in the calling array.
+// Errors returned by the func are collected as set by opts.ErrorMode and wrapped in a *lists.ElementError, elements which failed are left to their zero value.
+// The context is given to every go routine, when it is done no more go routine is started.
+func MapAsyncErrTo[T any, U any](ctx context.Context, c []T, cb func(context.Context, int, T) (U, error), opts lists.Options) ([]U, error) {
+	var ret = make([]U, len(c))
+	err := async.MapErr(
+		ctx,
+		len(c),
+		opts,
+		async.Index,
+		func(ctx context.Context, i int) (U, error) {
+			v, err := cb(ctx, i, c[i])
+			if err != nil {
+				return v, &lists.ElementError{Key: i, Err: err}
+			}
+			return v, nil
+		},
+		func(i int, v U) {
+			ret[i] = v
+		},
+	)
+	return ret, err
+}
+
+// MapAsyncRetryTo func is MapAsyncErrTo returning the lists.Outcome of every element: its value, the error of its last attempt and its number of attempts.
+// Failed elements are retried as set by opts.Retry. Elements not done when the map stops are left to their zero value, with no attempt.
+func MapAsyncRetryTo[T any, U any](ctx context.Context, c []T, cb func(context.Context, int, T) (U, error), opts lists.Options) ([]lists.Outcome[U], error) {
+	var ret = make([]lists.Outcome[U], len(c))
+	err := async.MapOutcomes(
+		ctx,
+		len(c),
+		opts,
+		async.Index,
+		func(ctx context.Context, i int) (U, error) {
+			v, err := cb(ctx, i, c[i])
+			if err != nil {
+				return v, &lists.ElementError{Key: i, Err: err}
+			}
+			return v, nil
+		},
+		func(i int, o lists.Outcome[U]) {
+			ret[i] = o
+		},
+	)
+	return ret, err
+}
+
+// MapParallelTo func creates a new slice with the values returned by calling a provided func as a go routine on every element in the calling array.
+// No more than workers go routines run at the same time, 0 means no limit.
+// Returns a slice of the type returned by the func.
+func MapParallelTo[T any, U any](c []T, cb func(int, T) U, workers int) []U {
+	var ret = make([]U, len(c))
+	async.Parallel(
+		len(c),
+		workers,
+		func(i int) U {
+			return cb(i, c[i])
+		},
+		func(i int, v U) {
+			ret[i] = v
+		},
+	)
+	return ret
+}
+
+// Reduce func applies a func against an accumulator and each element in the slice (from left to right) to reduce it to a single value of any type.
+// For asynchronicity, see ReduceAsync.
+func Reduce[T any, A any](c []T, cb func(int, T, A) A, agg A) A {
+	for k, v := range c {
+		agg = cb(k, v, agg)
+	}
+	return agg
+}
+
+// ReduceAsync func applies a go routine against an accumulator and each element in the slice (from left to right) to reduce it to a single value of any type.
+// You must read the aggregator from the lists.Aggregator.Agg channel before writing to the lists.Aggregator.Done channel.
+// Go routines are run in series waiting for the previous go routine writing to the lists.Aggregator.Done channel.
+// For synchronicity, see Reduce.
+func ReduceAsync[T any, A any](c []T, cb func(int, T, *lists.Aggregator[A]), agg A) A {
+	return async.Reduce(
+		len(c),
+		func(i int, agg *lists.Aggregator[A]) {
+			cb(i, c[i], agg)
+		},
+		agg,
+	)
+}
+
+// ReduceAsyncContext func is ReduceAsync with a context given to every go routine.
+// When the context is done, no more go routine is started and the current state of the accumulator is returned with ctx.Err().
+// Panics are recovered as set by opts.OnPanic, the state of the accumulator is left unchanged by a go routine which panicked.
+func ReduceAsyncContext[T any, A any](ctx context.Context, c []T, cb func(context.Context, int, T, *lists.Aggregator[A]), agg A, opts lists.Options) (A, error) {
+	return async.ReduceContext(
+		ctx,
+		len(c),
+		opts,
+		async.Index,
+		func(ctx context.Context, i int, agg *lists.Aggregator[A]) {
+			cb(ctx, i, c[i], agg)
+		},
+		agg,
+	)
+}
@@ -0,0 +1,76 @@
+package slices
+
+import (
+	"context"
+	"testing"
+
+	"github.com/francoispqt/lists"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnySlice(t *testing.T) {
+	var test = AnySlice[[]string]{{"hello"}, {"foo", "bar"}}
+
+	assert.Equal(t, []int{0, 1}, test.Indexes(), "indexes should contain the indexes")
+
+	mapped := test.Map(func(k int, v []string) []string {
+		return append(v, "!")
+	})
+	assert.Equal(t, AnySlice[[]string]{{"hello", "!"}, {"foo", "bar", "!"}}, mapped, "map should append to every element")
+
+	ret := test.MapAsync(func(k int, v []string, done chan<- lists.Result[int, []string]) {
+		done <- lists.Result[int, []string]{Key: k, Value: v[:1]}
+	}, 1)
+	assert.Equal(t, AnySlice[[]string]{{"hello"}, {"foo"}}, ret, "map async should map back to original index")
+
+	total := Reduce(test, func(k int, v []string, agg int) int {
+		return agg + len(v)
+	}, 0)
+	assert.Equal(t, 3, total, "total should be 3")
+
+	filtered := test.Filter(func(k int, v []string) bool {
+		return len(v) > 1
+	})
+	assert.Len(t, filtered, 1, "len after filter should be 1")
+	assert.True(t, filtered.IsLast(0), "0 should be last index")
+}
+
+func TestMapTo(t *testing.T) {
+	var test = StringSlice{"1", "22", "333"}
+
+	lens := MapTo(test, func(k int, v string) int {
+		return len(v)
+	})
+	assert.Equal(t, []int{1, 2, 3}, lens, "map to should give the len of every element")
+
+	for _, maxConc := range []int{0, 2} {
+		ret := MapAsyncTo(test, func(k int, v string, done chan<- lists.Result[int, []byte]) {
+			go func() {
+				done <- lists.Result[int, []byte]{Key: k, Value: []byte(v)}
+			}()
+		}, maxConc)
+		assert.Equal(t, [][]byte{[]byte("1"), []byte("22"), []byte("333")}, ret, "map async to should map back to original index")
+	}
+}
+
+func TestChan(t *testing.T) {
+	ctx := context.Background()
+
+	var ints IntSlice = FromChan(IntSlice{1, 2, 3}.ToChan(ctx, 0), 0)
+	assert.Equal(t, IntSlice{2, 4, 6}, ints.Map(func(k int, v int) int {
+		return v * 2
+	}), "the slice read from the chan should be mapped")
+	assert.Equal(t, []string{"foo", "bar"}, FromChan(StringSlice{"foo", "bar", "baz"}.ToChan(ctx, 3), 2), "only limit values should be read")
+	assert.Equal(t, []float64{1.5}, FromChan(Float64Slice{1.5}.ToChan(ctx, 1), 0), "float64 slice should be written to the chan")
+	assert.Equal(t, []float32{1.5}, FromChan(Float32Slice{1.5}.ToChan(ctx, 1), 0), "float32 slice should be written to the chan")
+	assert.Equal(t, []interface{}{"foo", 1}, FromChan(InterfaceSlice{"foo", 1}.ToChan(ctx, 0), 0), "interface slice should be written to the chan")
+	assert.Equal(t, []int{1, 2}, FromChan(Slice[int]{1, 2}.ToChan(ctx, 0), 0), "generic slice should be written to the chan")
+	assert.Equal(t, [][]string{{"foo"}}, FromChan(AnySlice[[]string]{{"foo"}}.ToChan(ctx, 0), 0), "any slice should be written to the chan")
+	assert.Equal(t, []int{}, FromChan(IntSlice{}.ToChan(ctx, 0), 0), "empty slice should give an empty chan")
+
+	cancelCtx, cancel := context.WithCancel(ctx)
+	c := IntSlice{1, 2, 3}.ToChan(cancelCtx, 0)
+	assert.Equal(t, 1, <-c, "first value should be 1")
+	cancel()
+	assert.LessOrEqual(t, len(FromChan(c, 0)), 1, "no more than the value being written should be read once ctx is done")
+}
@@ -1,240 +1,136 @@
 package slices
 
-import "github.com/francoispqt/lists"
+import (
+	"context"
 
-// Float64Slice is a custom type for a slice of float32
+	"github.com/francoispqt/lists"
+)
+
+// Float32Slice is a custom type for a slice of float32.
+// It is kept for compatibility, its methods delegate to Slice[float32].
 type Float32Slice []float32
 
 // Contains method determines whether a slice includes a certain element, returning true or false as appropriate.
+// It is a linear scan, for a sorted slice see BinarySearch.
 func (c Float32Slice) Contains(s float32) bool {
-	for _, v := range c {
-		if v == s {
-			return true
-		}
-	}
-	return false
+	return Slice[float32](c).Contains(s)
 }
 
 // ForEach method executes a provided func once for each slice element.
 func (c Float32Slice) ForEach(cb func(int, float32)) {
-	for k, v := range c {
-		cb(k, v)
-	}
+	Slice[float32](c).ForEach(cb)
 }
 
-// MapInterface method creates a new slice with the results of calling a provided func on every element in the calling array.
-// Returns a slice of string (original type).
+// Map method creates a new slice with the results of calling a provided func on every element in the calling array.
+// Returns a Float32Slice (original type).
 // For asynchronicity, see MapAsync.
 func (c Float32Slice) Map(cb func(int, float32) float32) Float32Slice {
-	var ret = make([]float32, len(c))
-	for k, v := range c {
-		ret[k] = cb(k, v)
-	}
-	return ret
+	return Float32Slice(Slice[float32](c).Map(cb))
 }
 
 // MapInterface method creates a new slice with the results of calling a provided func on every element in the calling array.
 // Returns a slice of interfaces.
 // For asynchronicity, see MapAsyncInterface.
 func (c Float32Slice) MapInterface(cb func(int, float32) interface{}) InterfaceSlice {
-	var ret = make([]interface{}, len(c))
-	for k, v := range c {
-		ret[k] = cb(k, v)
-	}
-	return ret
+	return Slice[float32](c).MapInterface(cb)
 }
 
 // MapAsync method creates a new slice with the results of calling a provided go routine on every element in the calling array.
-// Runs asynchronously and needs gives a chan [2]interface{} to return results.
-// To keep initial order, the first elemt of th []interface{} written to the chan must be the key. The second element muse be a string.
-// Returns a StringSlice (original type).
-// If you want to map to a slice of different type, see MapAsyncInterface.
+// Runs asynchronously and gives a chan [2]interface{} to return results.
+// To keep initial order, the first element of the [2]interface{} written to the chan must be the index. The second element must be a float32.
+// Returns a Float32Slice (original type).
+// For a typed chan, see Slice.MapAsync.
 func (c Float32Slice) MapAsync(cb func(int, float32, chan [2]interface{}), maxConcurrency ...int) Float32Slice {
-	var maxConc = lists.DEFAULT_CONC
-	if len(maxConcurrency) == 1 {
-		maxConc = maxConcurrency[0]
-	}
-
-	var mapChan = make(chan [2]interface{}, len(c))
-	var doing chan struct{}
-	var ret = make([]float32, len(c))
-	var i = 0
-	var received = 0
+	var ret = make(Float32Slice, len(c))
+	mapAsyncIntf(c, cb, maxConcurrency, func(i int, v float32) {
+		ret[i] = v
+	})
+	return ret
+}
 
-	if maxConc > 0 {
-		doing = make(chan struct{}, maxConc)
-	} else {
-		doing = make(chan struct{})
-	}
+// MapAsyncContext method is MapAsync with a context given to every go routine.
+// When the context is done, no more go routine is started and MapAsyncContext returns ctx.Err() with the results received so far.
+func (c Float32Slice) MapAsyncContext(ctx context.Context, cb func(context.Context, int, float32, chan [2]interface{}), opts lists.Options) (Float32Slice, error) {
+	var ret = make(Float32Slice, len(c))
+	err := mapAsyncIntfContext(ctx, c, cb, opts, func(i int, v float32) {
+		ret[i] = v
+	})
+	return ret, err
+}
 
-	for {
-
-		// if maxConc == 0 go ahead or if maxConc is higher than 0 length of chan doing is lower than maxConc && counter is lower than lenght of slice continue
-		// else start reading from the chan to decrease concurrency
-		if maxConc == 0 || (len(doing) < maxConc && i < len(c)) {
-			v := c[i]
-			go cb(i, v, mapChan)
-			i++
-			if maxConc > 0 {
-				doing <- struct{}{}
-			}
-			if maxConc == 0 && i == len(c) {
-				break
-			}
-		} else {
-
-			// start reading my chan
-			intf := <-mapChan
-			received++
-
-			if len(intf) > 1 {
-				ret[intf[0].(int)] = intf[1].(float32)
-			} else {
-				ret[intf[0].(int)] = 0
-			}
-
-			// reading doing to continue the loop
-			<-doing
-
-			if received == len(c) {
-				return ret
-			}
-		}
-	}
+// MapAsyncErr method creates a new slice with the values returned by calling a provided func as a go routine on every element in the calling array.
+// Errors returned by the func are collected as set by opts.ErrorMode and wrapped in a *lists.ElementError, elements which failed are left to their zero value.
+// The context is given to every go routine, when it is done no more go routine is started.
+func (c Float32Slice) MapAsyncErr(ctx context.Context, cb func(context.Context, int, float32) (float32, error), opts lists.Options) (Float32Slice, error) {
+	return MapAsyncErrTo(ctx, c, cb, opts)
+}
 
-	// is max concurenccy is 0, means no limit
-	// so we only start reading the result chan here
-	if maxConc == 0 {
-		ct := 0
-		for intf := range mapChan {
-			if len(intf) > 1 {
-				ret[intf[0].(int)] = intf[1].(float32)
-			} else {
-				ret[intf[0].(int)] = 0
-			}
-			ct++
-			if ct >= len(c) {
-				break
-			}
-		}
-	}
+// MapAsyncStream method calls a provided go routine on every element in the calling array and returns a chan yielding the results as soon as they are written.
+// With opts.Ordered, results are yielded in the order of the elements.
+// The chan is closed once every go routine is done, the error of the call (nil on success) is then written to the error chan.
+func (c Float32Slice) MapAsyncStream(ctx context.Context, cb func(context.Context, int, float32, chan<- lists.Result[int, float32]), opts lists.Options) (<-chan lists.Result[int, float32], <-chan error) {
+	return MapAsyncStreamTo(ctx, c, cb, opts)
+}
 
-	return ret
+// MapParallel method creates a new slice with the values returned by calling a provided func as a go routine on every element in the calling array.
+// No more than workers go routines run at the same time, 0 means no limit.
+// Returns a Float32Slice (original type).
+func (c Float32Slice) MapParallel(cb func(int, float32) float32, workers int) Float32Slice {
+	return MapParallelTo(c, cb, workers)
 }
 
 // MapAsyncInterface method creates a new slice with the results of calling a provided go routine on every element in the calling array.
-// Runs asynchronously and needs gives a chan [2]interface{} to return results.
-// To keep initial order, the first elemt of th []interface{} written to the chan must be the key. The second element muse be a string.
+// Runs asynchronously and gives a chan [2]interface{} to return results.
+// To keep initial order, the first element of the [2]interface{} written to the chan must be the index.
 // Returns InterfaceSlice.
-// If you know the result will be of original type, user MapAsync.
+// If you know the result will be of original type, use MapAsync.
 func (c Float32Slice) MapAsyncInterface(cb func(int, float32, chan [2]interface{}), maxConcurrency ...int) InterfaceSlice {
-	var maxConc = lists.DEFAULT_CONC
-	if len(maxConcurrency) == 1 {
-		maxConc = maxConcurrency[0]
-	}
-
-	var mapChan = make(chan [2]interface{}, len(c))
-	var doing chan struct{}
-	var ret = make([]interface{}, len(c))
-	var i = 0
-	var received = 0
-
-	if maxConc > 0 {
-		doing = make(chan struct{}, maxConc)
-	} else {
-		doing = make(chan struct{})
-	}
-
-	for {
-
-		// if maxConc == 0 go ahead or if maxConc is higher than 0 length of chan doing is lower than maxConc && counter is lower than lenght of slice continue
-		// else start reading from the chan to decrease concurrency
-		if maxConc == 0 || (len(doing) < maxConc && i < len(c)) {
-			v := c[i]
-			go cb(i, v, mapChan)
-			i++
-			if maxConc > 0 {
-				doing <- struct{}{}
-			}
-			if maxConc == 0 && i == len(c) {
-				break
-			}
-		} else {
-
-			// start reading my chan
-			intf := <-mapChan
-			received++
-
-			if len(intf) > 1 {
-				ret[intf[0].(int)] = intf[1]
-			} else {
-				ret[intf[0].(int)] = nil
-			}
-
-			// reading doing to continue the loop
-			<-doing
-
-			if received == len(c) {
-				return ret
-			}
-		}
-	}
-
-	// is max concurenccy is 0, means no limit
-	// so we only start reading the result chan here
-	if maxConc == 0 {
-		ct := 0
-		for intf := range mapChan {
-			if len(intf) > 1 {
-				ret[intf[0].(int)] = intf[1]
-			} else {
-				ret[intf[0].(int)] = nil
-			}
-			ct++
-			if ct >= len(c) {
-				break
-			}
-		}
-	}
-
+	var ret = make(InterfaceSlice, len(c))
+	mapAsyncIntf(c, cb, maxConcurrency, func(i int, v interface{}) {
+		ret[i] = v
+	})
 	return ret
 }
 
+// MapAsyncInterfaceContext method is MapAsyncInterface with a context given to every go routine.
+// When the context is done, no more go routine is started and MapAsyncInterfaceContext returns ctx.Err() with the results received so far.
+func (c Float32Slice) MapAsyncInterfaceContext(ctx context.Context, cb func(context.Context, int, float32, chan [2]interface{}), opts lists.Options) (InterfaceSlice, error) {
+	var ret = make(InterfaceSlice, len(c))
+	err := mapAsyncIntfContext(ctx, c, cb, opts, func(i int, v interface{}) {
+		ret[i] = v
+	})
+	return ret, err
+}
+
 // Reduce method applies a func against an accumulator and each element in the slice (from left to right) to reduce it to a single value of any type.
 // If no accumulator is passed as second argument, default accumulator will be nil
 // Returns an interface.
 // For asynchronicity, see ReduceAsync.
 func (c Float32Slice) Reduce(cb func(int, float32, interface{}) interface{}, defAgg ...interface{}) interface{} {
 	var agg interface{}
-	if len(defAgg) == 0 {
-		agg = nil
-	} else {
+	if len(defAgg) > 0 {
 		agg = defAgg[0]
 	}
-	for k, v := range c {
-		agg = cb(k, v, agg)
-	}
-	return agg
+	return Reduce(c, cb, agg)
 }
 
-// Reduce method applies a go routinge against an accumulator and each element in the slice (from left to right) to reduce it to a single value of any type.
+// ReduceAsync method applies a go routine against an accumulator and each element in the slice (from left to right) to reduce it to a single value of any type.
+// You must read the aggregator from the lists.AsyncAggregator.Agg channel before writing to the lists.AsyncAggregator.Done channel.
 // Returns an interface.
 // For synchronicity, see Reduce.
 func (c Float32Slice) ReduceAsync(cb func(int, float32, *lists.AsyncAggregator), defAgg ...interface{}) interface{} {
-	agg := &lists.AsyncAggregator{
-		Done: make(chan interface{}, len(c)),
-		Agg:  make(chan interface{}, len(c)),
-	}
-	if len(defAgg) == 0 {
-		agg.Agg <- nil
-	} else {
-		agg.Agg <- defAgg[0]
-	}
-	for k, v := range c {
-		go cb(k, v, agg)
-		agg.Agg <- <-agg.Done
+	var agg interface{}
+	if len(defAgg) > 0 {
+		agg = defAgg[0]
 	}
-	return <-agg.Agg
+	return ReduceAsync(c, cb, agg)
+}
+
+// ReduceAsyncContext method is ReduceAsync with a context given to every go routine.
+// When the context is done, no more go routine is started and the current state of the accumulator is returned with ctx.Err().
+// Panics are recovered as set by opts.OnPanic, the state of the accumulator is left unchanged by a go routine which panicked.
+func (c Float32Slice) ReduceAsyncContext(ctx context.Context, cb func(context.Context, int, float32, *lists.AsyncAggregator), agg interface{}, opts lists.Options) (interface{}, error) {
+	return ReduceAsyncContext(ctx, c, cb, agg, opts)
 }
 
 // IsLast checks if the index passed is the last of the slice
@@ -244,27 +140,100 @@ func (c Float32Slice) IsLast(i int) bool {
 
 // Indexes returns a slice of ints with including the indexes of the Float32Slice
 func (c Float32Slice) Indexes() []int {
-	var indexes = []int{}
-	for i := 0; i < len(c); i++ {
-		indexes = append(indexes, i)
-	}
-	return indexes
+	return Slice[float32](c).Indexes()
 }
 
 // Filter method creates a new slice with all elements that pass the test implemented by the provided function.
 func (c Float32Slice) Filter(cb func(k int, v float32) bool) Float32Slice {
-	var ret = make([]float32, 0)
-	for k, v := range c {
-		if cb(k, v) {
-			ret = append(ret, v)
-		}
-	}
-	return ret
+	return Float32Slice(Slice[float32](c).Filter(cb))
+}
+
+// Sort method creates a new slice with the elements sorted in ascending order, NaNs first.
+// Returns a Float32Slice (original type).
+func (c Float32Slice) Sort() Float32Slice {
+	return Sort(c)
+}
+
+// SortDesc method creates a new slice with the elements sorted in descending order, NaNs last.
+// Returns a Float32Slice (original type).
+func (c Float32Slice) SortDesc() Float32Slice {
+	return SortDesc(c)
+}
+
+// SortStable method creates a new slice with the elements sorted by the provided less func, equal elements keeping their order.
+// Returns a Float32Slice (original type). To sort by a key, see SortBy.
+func (c Float32Slice) SortStable(less func(a, b float32) bool) Float32Slice {
+	return SortStable(c, less)
+}
+
+// IsSorted method reports whether the elements are sorted in ascending order, NaNs first.
+func (c Float32Slice) IsSorted() bool {
+	return IsSorted(c)
+}
+
+// BinarySearch method returns the index of the first element equal to v and true,
+// or the index at which v would be inserted and false if the slice does not contain v.
+// The slice must be sorted in the order of Sort.
+func (c Float32Slice) BinarySearch(v float32) (int, bool) {
+	return binarySearchFunc(c, v, less[float32])
+}
+
+// LowerBound method returns the index of the first element not lower than v, len(c) if there is none.
+// The slice must be sorted in the order of Sort.
+func (c Float32Slice) LowerBound(v float32) int {
+	return lowerBoundFunc(c, v, less[float32])
+}
+
+// UpperBound method returns the index of the first element greater than v, len(c) if there is none.
+// The slice must be sorted in the order of Sort.
+func (c Float32Slice) UpperBound(v float32) int {
+	return upperBoundFunc(c, v, less[float32])
+}
+
+// InsertSorted method creates a new slice with v inserted after the elements equal to it, so that it stays sorted.
+// The slice must be sorted in the order of Sort. Returns a Float32Slice (original type).
+func (c Float32Slice) InsertSorted(v float32) Float32Slice {
+	return insertSortedFunc(c, v, less[float32])
+}
+
+// RangeBetween method returns the elements between lo and hi included, as a sub-slice sharing its elements with c.
+// The slice must be sorted in the order of Sort. Returns a Float32Slice (original type).
+func (c Float32Slice) RangeBetween(lo, hi float32) Float32Slice {
+	return rangeBetweenFunc(c, lo, hi, less[float32])
+}
+
+// Union method creates a new slice with the elements of c then the ones of s, without duplicates, in their order.
+// Returns a Float32Slice (original type).
+func (c Float32Slice) Union(s Float32Slice) Float32Slice {
+	return Union(c, s)
+}
+
+// Intersect method creates a new slice with the elements of c which are in s, without duplicates, in their order.
+// Returns a Float32Slice (original type).
+func (c Float32Slice) Intersect(s Float32Slice) Float32Slice {
+	return Intersect(c, s)
+}
+
+// Difference method creates a new slice with the elements of c which are not in s, without duplicates, in their order.
+// Returns a Float32Slice (original type).
+func (c Float32Slice) Difference(s Float32Slice) Float32Slice {
+	return Difference(c, s)
+}
+
+// SymmetricDifference method creates a new slice with the elements of c which are not in s then the ones of s which are not in c,
+// without duplicates, in their order.
+// Returns a Float32Slice (original type).
+func (c Float32Slice) SymmetricDifference(s Float32Slice) Float32Slice {
+	return SymmetricDifference(c, s)
+}
+
+// ToChan method returns a chan yielding the elements of the slice in order, with a buffer of the given size.
+// The chan is closed once every element is written or ctx is done.
+func (c Float32Slice) ToChan(ctx context.Context, buffer int) <-chan float32 {
+	return AnySlice[float32](c).ToChan(ctx, buffer)
 }
 
-// Cast explicitly cast the StringSlice to a []float32 type
+// Cast explicitly cast the Float32Slice to a []float32 type
 func (c Float32Slice) Cast() []float32 {
-	var dest []float32
-	dest = c
-	return dest
+	return c
 }
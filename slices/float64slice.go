@@ -1,241 +1,147 @@
 package slices
 
-import "github.com/francoispqt/lists"
+import (
+	"context"
 
-// Float64Slice is a custom type for a slice of float64
+	"github.com/francoispqt/lists"
+)
+
+// Float64Slice is a custom type for a slice of float64.
+// It is kept for compatibility, its methods delegate to Slice[float64].
 type Float64Slice []float64
 
 // Contains method determines whether a slice includes a certain element, returning true or false as appropriate.
+// It is a linear scan, for a sorted slice see BinarySearch.
 func (c Float64Slice) Contains(s float64) bool {
-	for _, v := range c {
-		if v == s {
-			return true
-		}
-	}
-	return false
+	return Slice[float64](c).Contains(s)
 }
 
 // ForEach method executes a provided func once for each slice element.
 func (c Float64Slice) ForEach(cb func(int, float64)) {
-	for k, v := range c {
-		cb(k, v)
-	}
+	Slice[float64](c).ForEach(cb)
 }
 
-// MapInterface method creates a new slice with the results of calling a provided func on every element in the calling array.
-// Returns a slice of string (original type).
+// Map method creates a new slice with the results of calling a provided func on every element in the calling array.
+// Returns a Float64Slice (original type).
 // For asynchronicity, see MapAsync.
 func (c Float64Slice) Map(cb func(int, float64) float64) Float64Slice {
-	var ret = make([]float64, len(c))
-	for k, v := range c {
-		ret[k] = cb(k, v)
-	}
-	return ret
+	return Float64Slice(Slice[float64](c).Map(cb))
 }
 
 // MapInterface method creates a new slice with the results of calling a provided func on every element in the calling array.
 // Returns a slice of interfaces.
 // For asynchronicity, see MapAsyncInterface.
 func (c Float64Slice) MapInterface(cb func(int, float64) interface{}) InterfaceSlice {
-	var ret = make([]interface{}, len(c))
-	for k, v := range c {
-		ret[k] = cb(k, v)
-	}
-	return ret
+	return Slice[float64](c).MapInterface(cb)
 }
 
 // MapAsync method creates a new slice with the results of calling a provided go routine on every element in the calling array.
-// Runs asynchronously and needs gives a chan [2]interface{} to return results.
-// To keep initial order, the first elemt of th []interface{} written to the chan must be the key. The second element muse be a string.
-// Returns a StringSlice (original type).
-// If you want to map to a slice of different type, see MapAsyncInterface.
+// Runs asynchronously and gives a chan [2]interface{} to return results.
+// To keep initial order, the first element of the [2]interface{} written to the chan must be the index. The second element must be a float64.
+// Returns a Float64Slice (original type).
+// For a typed chan, see Slice.MapAsync.
 func (c Float64Slice) MapAsync(cb func(int, float64, chan [2]interface{}), maxConcurrency ...int) Float64Slice {
-	var maxConc = lists.DEFAULT_CONC
-	if len(maxConcurrency) == 1 {
-		maxConc = maxConcurrency[0]
-	}
-
-	var mapChan = make(chan [2]interface{}, len(c))
-	var doing chan struct{}
-	var ret = make([]float64, len(c))
-	var i = 0
-	var received = 0
+	var ret = make(Float64Slice, len(c))
+	mapAsyncIntf(c, cb, maxConcurrency, func(i int, v float64) {
+		ret[i] = v
+	})
+	return ret
+}
 
-	if maxConc > 0 {
-		doing = make(chan struct{}, maxConc)
-	} else {
-		doing = make(chan struct{})
-	}
+// MapAsyncContext method is MapAsync with a context given to every go routine.
+// When the context is done, no more go routine is started and MapAsyncContext returns ctx.Err() with the results received so far.
+func (c Float64Slice) MapAsyncContext(ctx context.Context, cb func(context.Context, int, float64, chan [2]interface{}), opts lists.Options) (Float64Slice, error) {
+	var ret = make(Float64Slice, len(c))
+	err := mapAsyncIntfContext(ctx, c, cb, opts, func(i int, v float64) {
+		ret[i] = v
+	})
+	return ret, err
+}
 
-	for {
-
-		// if maxConc == 0 go ahead or if maxConc is higher than 0 length of chan doing is lower than maxConc && counter is lower than lenght of slice continue
-		// else start reading from the chan to decrease concurrency
-		if maxConc == 0 || (len(doing) < maxConc && i < len(c)) {
-			v := c[i]
-			go cb(i, v, mapChan)
-			i++
-			if maxConc > 0 {
-				doing <- struct{}{}
-			}
-			if maxConc == 0 && i == len(c) {
-				break
-			}
-		} else {
-
-			// start reading my chan
-			intf := <-mapChan
-			received++
-
-			if len(intf) > 1 {
-				ret[intf[0].(int)] = intf[1].(float64)
-			} else {
-				ret[intf[0].(int)] = 0
-			}
-
-			// reading doing to continue the loop
-			<-doing
-
-			if received == len(c) {
-				return ret
-			}
-		}
-	}
+// MapAsyncErr method creates a new slice with the values returned by calling a provided func as a go routine on every element in the calling array.
+// Errors returned by the func are collected as set by opts.ErrorMode and wrapped in a *lists.ElementError, elements which failed are left to their zero value.
+// The context is given to every go routine, when it is done no more go routine is started.
+func (c Float64Slice) MapAsyncErr(ctx context.Context, cb func(context.Context, int, float64) (float64, error), opts lists.Options) (Float64Slice, error) {
+	return MapAsyncErrTo(ctx, c, cb, opts)
+}
 
-	// is max concurenccy is 0, means no limit
-	// so we only start reading the result chan here
-	if maxConc == 0 {
-		ct := 0
-		for intf := range mapChan {
-			if len(intf) > 1 {
-				ret[intf[0].(int)] = intf[1].(float64)
-			} else {
-				ret[intf[0].(int)] = 0
-			}
-			ct++
-			if ct >= len(c) {
-				break
-			}
-		}
-	}
+// MapAsyncStream method calls a provided go routine on every element in the calling array and returns a chan yielding the results as soon as they are written.
+// With opts.Ordered, results are yielded in the order of the elements.
+// The chan is closed once every go routine is done, the error of the call (nil on success) is then written to the error chan.
+func (c Float64Slice) MapAsyncStream(ctx context.Context, cb func(context.Context, int, float64, chan<- lists.Result[int, float64]), opts lists.Options) (<-chan lists.Result[int, float64], <-chan error) {
+	return MapAsyncStreamTo(ctx, c, cb, opts)
+}
 
-	return ret
+// MapParallel method creates a new slice with the values returned by calling a provided func as a go routine on every element in the calling array.
+// No more than workers go routines run at the same time, 0 means no limit.
+// Returns a Float64Slice (original type).
+func (c Float64Slice) MapParallel(cb func(int, float64) float64, workers int) Float64Slice {
+	return MapParallelTo(c, cb, workers)
 }
 
 // MapAsyncInterface method creates a new slice with the results of calling a provided go routine on every element in the calling array.
-// Runs asynchronously and needs gives a chan [2]interface{} to return results.
-// To keep initial order, the first elemt of th []interface{} written to the chan must be the key. The second element muse be a string.
+// Runs asynchronously and gives a chan [2]interface{} to return results.
+// To keep initial order, the first element of the [2]interface{} written to the chan must be the index.
 // Returns InterfaceSlice.
-// If you know the result will be of original type, user MapAsync.
+// If you know the result will be of original type, use MapAsync.
 func (c Float64Slice) MapAsyncInterface(cb func(int, float64, chan [2]interface{}), maxConcurrency ...int) InterfaceSlice {
-	var maxConc = lists.DEFAULT_CONC
-	if len(maxConcurrency) == 1 {
-		maxConc = maxConcurrency[0]
-	}
-
-	var mapChan = make(chan [2]interface{}, len(c))
-	var doing chan struct{}
-	var ret = make([]interface{}, len(c))
-	var i = 0
-	var received = 0
-
-	if maxConc > 0 {
-		doing = make(chan struct{}, maxConc)
-	} else {
-		doing = make(chan struct{})
-	}
-
-	for {
-
-		// if maxConc == 0 go ahead or if maxConc is higher than 0 length of chan doing is lower than maxConc && counter is lower than lenght of slice continue
-		// else start reading from the chan to decrease concurrency
-		if maxConc == 0 || (len(doing) < maxConc && i < len(c)) {
-			v := c[i]
-			go cb(i, v, mapChan)
-			i++
-			if maxConc > 0 {
-				doing <- struct{}{}
-			}
-			if maxConc == 0 && i == len(c) {
-				break
-			}
-		} else {
-
-			// start reading my chan
-			intf := <-mapChan
-			received++
-
-			if len(intf) > 1 {
-				ret[intf[0].(int)] = intf[1]
-			} else {
-				ret[intf[0].(int)] = nil
-			}
-
-			// reading doing to continue the loop
-			<-doing
-
-			if received == len(c)-1 {
-				return ret
-			}
-			continue
-		}
-	}
-
-	// is max concurenccy is 0, means no limit
-	// so we only start reading the result chan here
-	if maxConc == 0 {
-		ct := 0
-		for intf := range mapChan {
-			if len(intf) > 1 {
-				ret[intf[0].(int)] = intf[1]
-			} else {
-				ret[intf[0].(int)] = nil
-			}
-			ct++
-			if ct >= len(c) {
-				break
-			}
-		}
-	}
-
+	var ret = make(InterfaceSlice, len(c))
+	mapAsyncIntf(c, cb, maxConcurrency, func(i int, v interface{}) {
+		ret[i] = v
+	})
 	return ret
 }
 
+// MapAsyncInterfaceContext method is MapAsyncInterface with a context given to every go routine.
+// When the context is done, no more go routine is started and MapAsyncInterfaceContext returns ctx.Err() with the results received so far.
+func (c Float64Slice) MapAsyncInterfaceContext(ctx context.Context, cb func(context.Context, int, float64, chan [2]interface{}), opts lists.Options) (InterfaceSlice, error) {
+	var ret = make(InterfaceSlice, len(c))
+	err := mapAsyncIntfContext(ctx, c, cb, opts, func(i int, v interface{}) {
+		ret[i] = v
+	})
+	return ret, err
+}
+
 // Reduce method applies a func against an accumulator and each element in the slice (from left to right) to reduce it to a single value of any type.
 // If no accumulator is passed as second argument, default accumulator will be nil
 // Returns an interface.
 // For asynchronicity, see ReduceAsync.
 func (c Float64Slice) Reduce(cb func(int, float64, interface{}) interface{}, defAgg ...interface{}) interface{} {
 	var agg interface{}
-	if len(defAgg) == 0 {
-		agg = nil
-	} else {
+	if len(defAgg) > 0 {
 		agg = defAgg[0]
 	}
-	for k, v := range c {
-		agg = cb(k, v, agg)
-	}
-	return agg
+	return Reduce(c, cb, agg)
 }
 
-// Reduce method applies a go routinge against an accumulator and each element in the slice (from left to right) to reduce it to a single value of any type.
+// ReduceAsync method applies a go routine against an accumulator and each element in the slice (from left to right) to reduce it to a single value of any type.
+// You must read the aggregator from the lists.AsyncAggregator.Agg channel before writing to the lists.AsyncAggregator.Done channel.
 // Returns an interface.
 // For synchronicity, see Reduce.
 func (c Float64Slice) ReduceAsync(cb func(int, float64, *lists.AsyncAggregator), defAgg ...interface{}) interface{} {
-	agg := &lists.AsyncAggregator{
-		Done: make(chan interface{}, len(c)),
-		Agg:  make(chan interface{}, len(c)),
-	}
-	if len(defAgg) == 0 {
-		agg.Agg <- nil
-	} else {
-		agg.Agg <- defAgg[0]
-	}
-	for k, v := range c {
-		go cb(k, v, agg)
-		agg.Agg <- <-agg.Done
+	var agg interface{}
+	if len(defAgg) > 0 {
+		agg = defAgg[0]
 	}
-	return <-agg.Agg
+	return ReduceAsync(c, cb, agg)
+}
+
+// ReduceAsyncContext method is ReduceAsync with a context given to every go routine.
+// When the context is done, no more go routine is started and the current state of the accumulator is returned with ctx.Err().
+// Panics are recovered as set by opts.OnPanic, the state of the accumulator is left unchanged by a go routine which panicked.
+func (c Float64Slice) ReduceAsyncContext(ctx context.Context, cb func(context.Context, int, float64, *lists.AsyncAggregator), agg interface{}, opts lists.Options) (interface{}, error) {
+	return ReduceAsyncContext(ctx, c, cb, agg, opts)
+}
+
+// ReduceParallel method combines the elements of the slice to a single value of the original type, using workers go routines.
+// The slice is split into one chunk per worker, each chunk is reduced from identity, then the partial results are combined in a tree.
+// combine must be associative (combine(combine(a, b), c) == combine(a, combine(b, c))) and identity must leave any value unchanged when combined with it,
+// elements are always combined in their order so combine needs not be commutative.
+// If workers is not higher than 0, runtime.GOMAXPROCS(0) workers are used.
+// As float additions are not exactly associative, sums may differ from the ones of Reduce in the last bits.
+// For a sequential reduction, see Reduce.
+func (c Float64Slice) ReduceParallel(identity float64, combine func(float64, float64) float64, workers int) float64 {
+	return Slice[float64](c).ReduceParallel(identity, combine, workers)
 }
 
 // IsLast checks if the index passed is the last of the slice
@@ -245,27 +151,107 @@ func (c Float64Slice) IsLast(i int) bool {
 
 // Indexes returns a slice of ints with including the indexes of the Float64Slice
 func (c Float64Slice) Indexes() []int {
-	var indexes = []int{}
-	for i := 0; i < len(c); i++ {
-		indexes = append(indexes, i)
-	}
-	return indexes
+	return Slice[float64](c).Indexes()
 }
 
 // Filter method creates a new slice with all elements that pass the test implemented by the provided function.
 func (c Float64Slice) Filter(cb func(k int, v float64) bool) Float64Slice {
-	var ret = make([]float64, 0)
-	for k, v := range c {
-		if cb(k, v) {
-			ret = append(ret, v)
-		}
-	}
-	return ret
+	return Float64Slice(Slice[float64](c).Filter(cb))
+}
+
+// Sort method creates a new slice with the elements sorted in ascending order, NaNs first.
+// Returns a Float64Slice (original type).
+func (c Float64Slice) Sort() Float64Slice {
+	return Sort(c)
+}
+
+// ParallelSort method creates a new slice with the elements sorted in ascending order, NaNs first, by a parallel merge sort.
+// If workers is not higher than 0, runtime.GOMAXPROCS(0) workers are used.
+// Returns a Float64Slice (original type). For a sequential sort, see Sort.
+func (c Float64Slice) ParallelSort(workers int) Float64Slice {
+	return ParallelSort(c, workers)
+}
+
+// SortDesc method creates a new slice with the elements sorted in descending order, NaNs last.
+// Returns a Float64Slice (original type).
+func (c Float64Slice) SortDesc() Float64Slice {
+	return SortDesc(c)
+}
+
+// SortStable method creates a new slice with the elements sorted by the provided less func, equal elements keeping their order.
+// Returns a Float64Slice (original type). To sort by a key, see SortBy.
+func (c Float64Slice) SortStable(less func(a, b float64) bool) Float64Slice {
+	return SortStable(c, less)
+}
+
+// IsSorted method reports whether the elements are sorted in ascending order, NaNs first.
+func (c Float64Slice) IsSorted() bool {
+	return IsSorted(c)
+}
+
+// BinarySearch method returns the index of the first element equal to v and true,
+// or the index at which v would be inserted and false if the slice does not contain v.
+// The slice must be sorted in the order of Sort.
+func (c Float64Slice) BinarySearch(v float64) (int, bool) {
+	return binarySearchFunc(c, v, less[float64])
+}
+
+// LowerBound method returns the index of the first element not lower than v, len(c) if there is none.
+// The slice must be sorted in the order of Sort.
+func (c Float64Slice) LowerBound(v float64) int {
+	return lowerBoundFunc(c, v, less[float64])
+}
+
+// UpperBound method returns the index of the first element greater than v, len(c) if there is none.
+// The slice must be sorted in the order of Sort.
+func (c Float64Slice) UpperBound(v float64) int {
+	return upperBoundFunc(c, v, less[float64])
+}
+
+// InsertSorted method creates a new slice with v inserted after the elements equal to it, so that it stays sorted.
+// The slice must be sorted in the order of Sort. Returns a Float64Slice (original type).
+func (c Float64Slice) InsertSorted(v float64) Float64Slice {
+	return insertSortedFunc(c, v, less[float64])
+}
+
+// RangeBetween method returns the elements between lo and hi included, as a sub-slice sharing its elements with c.
+// The slice must be sorted in the order of Sort. Returns a Float64Slice (original type).
+func (c Float64Slice) RangeBetween(lo, hi float64) Float64Slice {
+	return rangeBetweenFunc(c, lo, hi, less[float64])
+}
+
+// Union method creates a new slice with the elements of c then the ones of s, without duplicates, in their order.
+// Returns a Float64Slice (original type).
+func (c Float64Slice) Union(s Float64Slice) Float64Slice {
+	return Union(c, s)
+}
+
+// Intersect method creates a new slice with the elements of c which are in s, without duplicates, in their order.
+// Returns a Float64Slice (original type).
+func (c Float64Slice) Intersect(s Float64Slice) Float64Slice {
+	return Intersect(c, s)
+}
+
+// Difference method creates a new slice with the elements of c which are not in s, without duplicates, in their order.
+// Returns a Float64Slice (original type).
+func (c Float64Slice) Difference(s Float64Slice) Float64Slice {
+	return Difference(c, s)
+}
+
+// SymmetricDifference method creates a new slice with the elements of c which are not in s then the ones of s which are not in c,
+// without duplicates, in their order.
+// Returns a Float64Slice (original type).
+func (c Float64Slice) SymmetricDifference(s Float64Slice) Float64Slice {
+	return SymmetricDifference(c, s)
+}
+
+// ToChan method returns a chan yielding the elements of the slice in order, with a buffer of the given size.
+// The chan is closed once every element is written or ctx is done.
+func (c Float64Slice) ToChan(ctx context.Context, buffer int) <-chan float64 {
+	return AnySlice[float64](c).ToChan(ctx, buffer)
 }
 
-// Cast explicitly cast the StringSlice to a []float64 type
+// Cast explicitly cast the Float64Slice to a []float64 type
 func (c Float64Slice) Cast() []float64 {
-	var dest []float64
-	dest = c
-	return dest
+	return c
 }
@@ -1,210 +1,263 @@
 package slices
 
 import (
+	"context"
 	"reflect"
 
 	"github.com/francoispqt/lists"
 )
 
-// InterfaceSlice is a custom type for a slice of interface{}
+// InterfaceSlice is a custom type for a slice of interface{}.
+// It is kept for compatibility, its methods delegate to Slice[interface{}].
 type InterfaceSlice []interface{}
 
 // Contains method determines whether a slice includes a certain element, returning true or false as appropriate.
+// It is a linear scan, for a sorted slice see BinarySearch.
+// Slices and arrays are compared element by element and must have the same len, NaNs are equal to each other,
+// values holding slices, maps or funcs are deeply compared.
 func (c InterfaceSlice) Contains(s interface{}) bool {
-	contains := false
 	for _, v := range c {
-		val := reflect.ValueOf(v)
-		sVal := reflect.ValueOf(s)
-		switch val.Kind() {
-		case reflect.Slice:
-			if sVal.Kind() == reflect.Slice {
-				contains = compare(v, s)
-				if contains {
-					return true
-				}
-			}
-			continue
-		case reflect.Array:
-			if sVal.Kind() == reflect.Array {
-				contains = compare(v, s)
-				if contains {
-					return true
-				}
-			}
-			continue
-		default:
-			if v == s {
-				return true
+		if equalElement(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// equalElement reports whether v and s are equal, as by Contains, BinarySearch and the set operations.
+// Slices and arrays are equal if they are of the same kind, of the same len and their elements are equal, compared alike.
+// NaNs of the same type are equal to each other. Other values are compared with == if no slice, map or func
+// can be reached from them, with reflect.DeepEqual otherwise.
+func equalElement(v, s interface{}) bool {
+	val, sVal := reflect.ValueOf(v), reflect.ValueOf(s)
+	switch val.Kind() {
+	case reflect.Slice, reflect.Array:
+		if sVal.Kind() != val.Kind() || sVal.Len() != val.Len() {
+			return false
+		}
+		for i := 0; i < val.Len(); i++ {
+			if !equalElement(val.Index(i).Interface(), sVal.Index(i).Interface()) {
+				return false
 			}
 		}
+		return true
+	}
+	if t, ok := nanType(v); ok {
+		sType, sNaN := nanType(s)
+		return sNaN && sType == t
 	}
-	return contains
+	if hashableValue(val) && hashableValue(sVal) {
+		return v == s
+	}
+	return reflect.DeepEqual(v, s)
 }
 
 // ForEach method executes a provided func once for each slice element.
 func (c InterfaceSlice) ForEach(cb func(int, interface{})) {
-	for k, v := range c {
-		cb(k, v)
-	}
+	Slice[interface{}](c).ForEach(cb)
 }
 
-// MapInterface method creates a new slice with the results of calling a provided func on every element in the calling array.
-// Returns a slice of string (original type).
+// Map method creates a new slice with the results of calling a provided func on every element in the calling array.
+// Returns an InterfaceSlice (original type).
 // For asynchronicity, see MapAsync.
 func (c InterfaceSlice) Map(cb func(int, interface{}) interface{}) InterfaceSlice {
-	var ret = make([]interface{}, len(c))
-	for k, v := range c {
-		ret[k] = cb(k, v)
-	}
-	return ret
+	return InterfaceSlice(Slice[interface{}](c).Map(cb))
 }
 
 // MapAsync method creates a new slice with the results of calling a provided go routine on every element in the calling array.
-// Runs asynchronously and needs gives a chan [2]interface{} to return results.
-// To keep initial order, the first elemt of th []interface{} written to the chan must be the key. The second element muse be a string.
-// Returns a StringSlice (original type).
-// If you want to map to a slice of different type, see MapAsyncInterface.
+// Runs asynchronously and gives a chan [2]interface{} to return results.
+// To keep initial order, the first element of the [2]interface{} written to the chan must be the index. The second element must be an interface{}.
+// Returns an InterfaceSlice (original type).
+// For a typed chan, see Slice.MapAsync.
 func (c InterfaceSlice) MapAsync(cb func(int, interface{}, chan [2]interface{}), maxConcurrency ...int) InterfaceSlice {
-	var maxConc = lists.DEFAULT_CONC
-	if len(maxConcurrency) == 1 {
-		maxConc = maxConcurrency[0]
-	}
-
-	var mapChan = make(chan [2]interface{}, len(c))
-	var doing chan struct{}
-	var ret = make([]interface{}, len(c))
-	var i = 0
-	var received = 0
-
-	if maxConc > 0 {
-		doing = make(chan struct{}, maxConc)
-	} else {
-		doing = make(chan struct{})
-	}
-
-	for {
-
-		// if maxConc == 0 go ahead or if maxConc is higher than 0 length of chan doing is lower than maxConc && counter is lower than lenght of slice continue
-		// else start reading from the chan to decrease concurrency
-		if maxConc == 0 || (len(doing) < maxConc && i < len(c)) {
-			v := c[i]
-			go cb(i, v, mapChan)
-			i++
-			if maxConc > 0 {
-				doing <- struct{}{}
-			}
-			if maxConc == 0 && i == len(c) {
-				break
-			}
-		} else {
-
-			// start reading my chan
-			intf := <-mapChan
-			received++
-
-			if len(intf) > 1 {
-				ret[intf[0].(int)] = intf[1]
-			} else {
-				ret[intf[0].(int)] = nil
-			}
+	var ret = make(InterfaceSlice, len(c))
+	mapAsyncIntf(c, cb, maxConcurrency, func(i int, v interface{}) {
+		ret[i] = v
+	})
+	return ret
+}
 
-			// reading doing to continue the loop
-			<-doing
+// MapAsyncContext method is MapAsync with a context given to every go routine.
+// When the context is done, no more go routine is started and MapAsyncContext returns ctx.Err() with the results received so far.
+func (c InterfaceSlice) MapAsyncContext(ctx context.Context, cb func(context.Context, int, interface{}, chan [2]interface{}), opts lists.Options) (InterfaceSlice, error) {
+	var ret = make(InterfaceSlice, len(c))
+	err := mapAsyncIntfContext(ctx, c, cb, opts, func(i int, v interface{}) {
+		ret[i] = v
+	})
+	return ret, err
+}
 
-			if received == len(c) {
-				return ret
-			}
-		}
-	}
+// MapAsyncErr method creates a new slice with the values returned by calling a provided func as a go routine on every element in the calling array.
+// Errors returned by the func are collected as set by opts.ErrorMode and wrapped in a *lists.ElementError, elements which failed are left to their zero value.
+// The context is given to every go routine, when it is done no more go routine is started.
+func (c InterfaceSlice) MapAsyncErr(ctx context.Context, cb func(context.Context, int, interface{}) (interface{}, error), opts lists.Options) (InterfaceSlice, error) {
+	return MapAsyncErrTo(ctx, c, cb, opts)
+}
 
-	// is max concurenccy is 0, means no limit
-	// so we only start reading the result chan here
-	if maxConc == 0 {
-		ct := 0
-		for intf := range mapChan {
-			if len(intf) > 1 {
-				ret[intf[0].(int)] = intf[1]
-			} else {
-				ret[intf[0].(int)] = nil
-			}
-			ct++
-			if ct >= len(c) {
-				break
-			}
-		}
-	}
+// MapAsyncStream method calls a provided go routine on every element in the calling array and returns a chan yielding the results as soon as they are written.
+// With opts.Ordered, results are yielded in the order of the elements.
+// The chan is closed once every go routine is done, the error of the call (nil on success) is then written to the error chan.
+func (c InterfaceSlice) MapAsyncStream(ctx context.Context, cb func(context.Context, int, interface{}, chan<- lists.Result[int, interface{}]), opts lists.Options) (<-chan lists.Result[int, interface{}], <-chan error) {
+	return MapAsyncStreamTo(ctx, c, cb, opts)
+}
 
-	return ret
+// MapParallel method creates a new slice with the values returned by calling a provided func as a go routine on every element in the calling array.
+// No more than workers go routines run at the same time, 0 means no limit.
+// Returns a InterfaceSlice (original type).
+func (c InterfaceSlice) MapParallel(cb func(int, interface{}) interface{}, workers int) InterfaceSlice {
+	return MapParallelTo(c, cb, workers)
 }
 
-// MapAsyncInterface method creates a new slice with the results of calling a provided go routine on every element in the calling array.
-// Runs asynchronously and needs gives a chan [2]interface{} to return results.
-// To keep initial order, the first elemt of th []interface{} written to the chan must be the key. The second element muse be a string.
-// Returns InterfaceSlice.
-// If you know the result will be of original type, user MapAsync.
+// Reduce method applies a func against an accumulator and each element in the slice (from left to right) to reduce it to a single value of any type.
+// If no accumulator is passed as second argument, default accumulator will be nil
+// Returns an interface.
+// For asynchronicity, see ReduceAsync.
 func (c InterfaceSlice) Reduce(cb func(int, interface{}, interface{}) interface{}, defAgg ...interface{}) interface{} {
 	var agg interface{}
-	if len(defAgg) == 0 {
-		agg = nil
-	} else {
+	if len(defAgg) > 0 {
 		agg = defAgg[0]
 	}
-	for k, v := range c {
-		agg = cb(k, v, agg)
-	}
-	return agg
+	return Reduce(c, cb, agg)
 }
 
-// Reduce method applies a go routinge against an accumulator and each element in the slice (from left to right) to reduce it to a single value of any type.
+// ReduceAsync method applies a go routine against an accumulator and each element in the slice (from left to right) to reduce it to a single value of any type.
+// You must read the aggregator from the lists.AsyncAggregator.Agg channel before writing to the lists.AsyncAggregator.Done channel.
 // Returns an interface.
 // For synchronicity, see Reduce.
 func (c InterfaceSlice) ReduceAsync(cb func(int, interface{}, *lists.AsyncAggregator), defAgg ...interface{}) interface{} {
-	agg := &lists.AsyncAggregator{
-		Done: make(chan interface{}, len(c)),
-		Agg:  make(chan interface{}, len(c)),
-	}
-	if len(defAgg) == 0 {
-		agg.Agg <- nil
-	} else {
-		agg.Agg <- defAgg[0]
-	}
-	for k, v := range c {
-		go cb(k, v, agg)
-		agg.Agg <- <-agg.Done
+	var agg interface{}
+	if len(defAgg) > 0 {
+		agg = defAgg[0]
 	}
-	return <-agg.Agg
+	return ReduceAsync(c, cb, agg)
+}
+
+// ReduceAsyncContext method is ReduceAsync with a context given to every go routine.
+// When the context is done, no more go routine is started and the current state of the accumulator is returned with ctx.Err().
+// Panics are recovered as set by opts.OnPanic, the state of the accumulator is left unchanged by a go routine which panicked.
+func (c InterfaceSlice) ReduceAsyncContext(ctx context.Context, cb func(context.Context, int, interface{}, *lists.AsyncAggregator), agg interface{}, opts lists.Options) (interface{}, error) {
+	return ReduceAsyncContext(ctx, c, cb, agg, opts)
 }
 
 // IsLast checks if the index passed is the last of the slice
-// @todo check if types are comparable
-// if slice perform slice compare
 func (c InterfaceSlice) IsLast(i int) bool {
 	return i == len(c)-1
 }
 
-// Indexes returns a slice of ints with including the indexes of the IntSlice
+// Indexes returns a slice of ints with including the indexes of the InterfaceSlice
 func (c InterfaceSlice) Indexes() []int {
-	var indexes = []int{}
-	for i := 0; i < len(c); i++ {
-		indexes = append(indexes, i)
-	}
-	return indexes
+	return Slice[interface{}](c).Indexes()
 }
 
 // Filter method creates a new slice with all elements that pass the test implemented by the provided function.
 func (c InterfaceSlice) Filter(cb func(k int, v interface{}) bool) InterfaceSlice {
-	var ret = make([]interface{}, 0)
-	for k, v := range c {
-		if cb(k, v) {
-			ret = append(ret, v)
+	return InterfaceSlice(Slice[interface{}](c).Filter(cb))
+}
+
+// Sort method creates a new slice with the elements sorted in ascending order: nil, then bools, then numbers of any type
+// compared by value (NaNs first), then strings, then any other value, keeping their order.
+// Returns an InterfaceSlice (original type).
+func (c InterfaceSlice) Sort() InterfaceSlice {
+	return SortStable(c, lessInterface)
+}
+
+// SortDesc method creates a new slice with the elements sorted in the reverse order of Sort.
+// Returns an InterfaceSlice (original type).
+func (c InterfaceSlice) SortDesc() InterfaceSlice {
+	return SortStable(c, func(a, b interface{}) bool {
+		return lessInterface(b, a)
+	})
+}
+
+// SortStable method creates a new slice with the elements sorted by the provided less func, equal elements keeping their order.
+// Returns an InterfaceSlice (original type). To sort by a key, see SortBy.
+func (c InterfaceSlice) SortStable(less func(a, b interface{}) bool) InterfaceSlice {
+	return SortStable(c, less)
+}
+
+// IsSorted method reports whether the elements are sorted in the order of Sort.
+func (c InterfaceSlice) IsSorted() bool {
+	return isSortedFunc(c, lessInterface)
+}
+
+// BinarySearch method returns the index of the first element equal to v and true,
+// or the index at which v would be inserted and false if the slice does not contain v.
+// Elements are equal as by Contains: among the elements which Sort orders as v, such as numbers of other types
+// of the same value or other values, the ones which are not equal to v are skipped.
+// The slice must be sorted in the order of Sort.
+func (c InterfaceSlice) BinarySearch(v interface{}) (int, bool) {
+	i := lowerBoundFunc(c, v, lessInterface)
+	for j := i; j < len(c) && !lessInterface(v, c[j]); j++ {
+		if equalElement(c[j], v) {
+			return j, true
 		}
 	}
-	return ret
+	return i, false
+}
+
+// LowerBound method returns the index of the first element not lower than v, len(c) if there is none.
+// Elements are compared in the order of Sort: numbers of any type by value, other values being equal to each other.
+// The slice must be sorted in the order of Sort.
+func (c InterfaceSlice) LowerBound(v interface{}) int {
+	return lowerBoundFunc(c, v, lessInterface)
+}
+
+// UpperBound method returns the index of the first element greater than v, len(c) if there is none.
+// Elements are compared in the order of Sort: numbers of any type by value, other values being equal to each other.
+// The slice must be sorted in the order of Sort.
+func (c InterfaceSlice) UpperBound(v interface{}) int {
+	return upperBoundFunc(c, v, lessInterface)
+}
+
+// InsertSorted method creates a new slice with v inserted after the elements equal to it, so that it stays sorted.
+// The slice must be sorted in the order of Sort. Returns an InterfaceSlice (original type).
+func (c InterfaceSlice) InsertSorted(v interface{}) InterfaceSlice {
+	return insertSortedFunc(c, v, lessInterface)
+}
+
+// RangeBetween method returns the elements between lo and hi included, as a sub-slice sharing its elements with c.
+// Elements are compared in the order of Sort: numbers of any type by value, other values being equal to each other.
+// The slice must be sorted in the order of Sort. Returns an InterfaceSlice (original type).
+func (c InterfaceSlice) RangeBetween(lo, hi interface{}) InterfaceSlice {
+	return rangeBetweenFunc(c, lo, hi, lessInterface)
+}
+
+// Union method creates a new slice with the elements of c then the ones of s, without duplicates, in their order.
+// Elements are compared as by Contains: slices and arrays element by element, with the same len.
+// Returns an InterfaceSlice (original type).
+func (c InterfaceSlice) Union(s InterfaceSlice) InterfaceSlice {
+	return unionIntf(c, s)
+}
+
+// Intersect method creates a new slice with the elements of c which are in s, without duplicates, in their order.
+// Elements are compared as by Contains: slices and arrays element by element, with the same len.
+// Returns an InterfaceSlice (original type).
+func (c InterfaceSlice) Intersect(s InterfaceSlice) InterfaceSlice {
+	return intersectIntf(c, s)
+}
+
+// Difference method creates a new slice with the elements of c which are not in s, without duplicates, in their order.
+// Elements are compared as by Contains: slices and arrays element by element, with the same len.
+// Returns an InterfaceSlice (original type).
+func (c InterfaceSlice) Difference(s InterfaceSlice) InterfaceSlice {
+	return differenceIntf(c, s)
+}
+
+// SymmetricDifference method creates a new slice with the elements of c which are not in s then the ones of s which are not in c,
+// without duplicates, in their order.
+// Elements are compared as by Contains: slices and arrays element by element, with the same len.
+// Returns an InterfaceSlice (original type).
+func (c InterfaceSlice) SymmetricDifference(s InterfaceSlice) InterfaceSlice {
+	return symmetricDifferenceIntf(c, s)
+}
+
+// ToChan method returns a chan yielding the elements of the slice in order, with a buffer of the given size.
+// The chan is closed once every element is written or ctx is done.
+func (c InterfaceSlice) ToChan(ctx context.Context, buffer int) <-chan interface{} {
+	return AnySlice[interface{}](c).ToChan(ctx, buffer)
 }
 
-// Cast explicitly cast the StringSlice to a []interface{} type
+// Cast explicitly cast the InterfaceSlice to a []interface{} type
 func (c InterfaceSlice) Cast() []interface{} {
-	var dest []interface{}
-	dest = c
-	return dest
+	return c
 }
@@ -0,0 +1,91 @@
+//go:build go1.23
+
+package slices
+
+import (
+	"iter"
+
+	"github.com/francoispqt/lists/iters"
+)
+
+// All method returns an iter.Seq2 yielding the indexes and elements of the slice in order.
+func (c AnySlice[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i, v := range c {
+			if !yield(i, v) {
+				return
+			}
+		}
+	}
+}
+
+// Values method returns a lazy iters.Iter yielding the elements of the slice in order.
+func (c AnySlice[T]) Values() iters.Iter[T] {
+	return func(yield func(T) bool) {
+		for _, v := range c {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// All method returns an iter.Seq2 yielding the indexes and elements of the slice in order.
+func (c Slice[T]) All() iter.Seq2[int, T] {
+	return AnySlice[T](c).All()
+}
+
+// Values method returns a lazy iters.Iter yielding the elements of the slice in order.
+func (c Slice[T]) Values() iters.Iter[T] {
+	return AnySlice[T](c).Values()
+}
+
+// All method returns an iter.Seq2 yielding the indexes and elements of the slice in order.
+func (c IntSlice) All() iter.Seq2[int, int] {
+	return AnySlice[int](c).All()
+}
+
+// Values method returns a lazy iters.Iter yielding the elements of the slice in order.
+func (c IntSlice) Values() iters.Iter[int] {
+	return AnySlice[int](c).Values()
+}
+
+// All method returns an iter.Seq2 yielding the indexes and elements of the slice in order.
+func (c StringSlice) All() iter.Seq2[int, string] {
+	return AnySlice[string](c).All()
+}
+
+// Values method returns a lazy iters.Iter yielding the elements of the slice in order.
+func (c StringSlice) Values() iters.Iter[string] {
+	return AnySlice[string](c).Values()
+}
+
+// All method returns an iter.Seq2 yielding the indexes and elements of the slice in order.
+func (c Float64Slice) All() iter.Seq2[int, float64] {
+	return AnySlice[float64](c).All()
+}
+
+// Values method returns a lazy iters.Iter yielding the elements of the slice in order.
+func (c Float64Slice) Values() iters.Iter[float64] {
+	return AnySlice[float64](c).Values()
+}
+
+// All method returns an iter.Seq2 yielding the indexes and elements of the slice in order.
+func (c Float32Slice) All() iter.Seq2[int, float32] {
+	return AnySlice[float32](c).All()
+}
+
+// Values method returns a lazy iters.Iter yielding the elements of the slice in order.
+func (c Float32Slice) Values() iters.Iter[float32] {
+	return AnySlice[float32](c).Values()
+}
+
+// All method returns an iter.Seq2 yielding the indexes and elements of the slice in order.
+func (c InterfaceSlice) All() iter.Seq2[int, interface{}] {
+	return AnySlice[interface{}](c).All()
+}
+
+// Values method returns a lazy iters.Iter yielding the elements of the slice in order.
+func (c InterfaceSlice) Values() iters.Iter[interface{}] {
+	return AnySlice[interface{}](c).Values()
+}
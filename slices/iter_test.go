@@ -0,0 +1,42 @@
+//go:build go1.23
+
+package slices
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIter(t *testing.T) {
+	var test = StringSlice{"foo", "", "bar"}
+
+	var indexes []int
+	var values []string
+	for i, v := range test.All() {
+		indexes = append(indexes, i)
+		values = append(values, v)
+	}
+	assert.Equal(t, []int{0, 1, 2}, indexes, "All should yield the indexes")
+	assert.Equal(t, []string{"foo", "", "bar"}, values, "All should yield the elements")
+
+	var upper StringSlice = test.Values().Filter(func(v string) bool {
+		return v != ""
+	}).Map(strings.ToUpper).Collect()
+	assert.Equal(t, StringSlice{"FOO", "BAR"}, upper, "Values should be filtered and mapped lazily")
+
+	assert.Equal(t, []int{2, 3}, IntSlice{1, 2, 3}.Values().Skip(1).Collect(), "int slice values")
+	assert.Equal(t, []float64{1.5}, Float64Slice{1.5}.Values().Collect(), "float64 slice values")
+	assert.Equal(t, []float32{1.5}, Float32Slice{1.5}.Values().Collect(), "float32 slice values")
+	assert.Equal(t, []interface{}{1, "a"}, InterfaceSlice{1, "a"}.Values().Collect(), "interface slice values")
+	assert.Equal(t, []int{1}, Slice[int]{1, 2}.Values().Take(1).Collect(), "generic slice values")
+	assert.Equal(t, [][]int{{1}}, AnySlice[[]int]{{1}}.Values().Collect(), "any slice values")
+
+	for i, v := range (IntSlice{1, 2, 3}).All() {
+		if i == 1 {
+			assert.Equal(t, 2, v, "element 1 should be 2")
+			break
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package slices
+
+import (
+	"runtime"
+	"sync"
+)
+
+// minParallelSortRun is the min number of elements sorted by a go routine of ParallelSort,
+// below it the cost of the go routine and of the merge is higher than what is saved.
+const minParallelSortRun = 1 << 12
+
+// ParallelSort func creates a new slice with the elements of c sorted in ascending order, NaNs first, by a parallel merge sort:
+// c is split into one run per worker, each run is sorted by its own go routine, then the runs are merged in pairs,
+// level by level, each merge in its own go routine.
+// If workers is not higher than 0, runtime.GOMAXPROCS(0) workers are used. Small slices are sorted by fewer workers,
+// each one sorting at least a few thousands elements.
+// Returns a slice of the type of c. For a sequential sort, see Sort.
+func ParallelSort[S ~[]T, T Ordered](c S, workers int) S {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if n := len(c) / minParallelSortRun; workers > n {
+		workers = n
+	}
+	if workers <= 1 {
+		return Sort(c)
+	}
+
+	var ret = append(make(S, 0, len(c)), c...)
+	// bounds[i] is the start of the run i, the last one is len(c)
+	bounds := make([]int, workers+1)
+	for i := range bounds {
+		bounds[i] = i * len(c) / workers
+	}
+	parallel(workers, func(i int) {
+		sortOrdered(ret[bounds[i]:bounds[i+1]])
+	})
+
+	// runs are merged back and forth between ret and a buffer
+	src, dst := []T(ret), make([]T, len(c))
+	for runs := len(bounds) - 1; runs > 1; runs = len(bounds) - 1 {
+		parallel((runs+1)/2, func(p int) {
+			lo, mid, hi := bounds[2*p], bounds[runs], bounds[runs]
+			if 2*p+1 < runs {
+				mid = bounds[2*p+1]
+			}
+			if 2*p+2 < runs {
+				hi = bounds[2*p+2]
+			}
+			merge(dst[lo:hi], src[lo:mid], src[mid:hi])
+		})
+		next := make([]int, 0, (runs+1)/2+1)
+		for i := 0; i < runs; i += 2 {
+			next = append(next, bounds[i])
+		}
+		bounds = append(next, len(c))
+		src, dst = dst, src
+	}
+	if &src[0] != &ret[0] {
+		copy(ret, src)
+	}
+	return ret
+}
+
+// merge merges the sorted slices a and b into dst, elements of a first when equal.
+func merge[T Ordered](dst, a, b []T) {
+	i, j, k := 0, 0, 0
+	for i < len(a) && j < len(b) {
+		if less(b[j], a[i]) {
+			dst[k] = b[j]
+			j++
+		} else {
+			dst[k] = a[i]
+			i++
+		}
+		k++
+	}
+	k += copy(dst[k:], a[i:])
+	copy(dst[k:], b[j:])
+}
+
+// parallel calls fn as a go routine for every index in [0, n) and waits for all of them.
+func parallel(n int, fn func(int)) {
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		go func(i int) {
+			defer wg.Done()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
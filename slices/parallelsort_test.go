@@ -0,0 +1,74 @@
+package slices
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func randomInts(n int) IntSlice {
+	r := rand.New(rand.NewSource(int64(n)))
+	var ret = make(IntSlice, n)
+	for i := range ret {
+		ret[i] = r.Intn(n)
+	}
+	return ret
+}
+
+func TestParallelSort(t *testing.T) {
+	for _, n := range []int{0, 10, minParallelSortRun * 2, minParallelSortRun*5 + 3, 100000} {
+		ints := randomInts(n)
+		for _, workers := range []int{0, 1, 2, 3, 8} {
+			sorted := ints.ParallelSort(workers)
+			assert.Equal(t, ints.Sort(), sorted, "ParallelSort should sort like Sort")
+			assert.True(t, sorted.IsSorted(), "ints should be sorted")
+		}
+		assert.Len(t, ints, n, "the original slice should be left unchanged")
+	}
+
+	// inputs which are the worst cases of naive quicksorts
+	ascending, descending, equal := make(IntSlice, 100000), make(IntSlice, 100000), make(IntSlice, 100000)
+	for i := range ascending {
+		ascending[i] = i
+		descending[i] = -i
+	}
+	for _, ints := range []IntSlice{ascending, descending, equal} {
+		assert.True(t, ints.ParallelSort(3).IsSorted(), "ints should be sorted")
+		assert.True(t, ints.Sort().IsSorted(), "ints should be sorted")
+	}
+
+	floats := make(Float64Slice, minParallelSortRun*4)
+	for i := range floats {
+		floats[i] = rand.NormFloat64()
+		if i%100 == 0 {
+			floats[i] = math.NaN()
+		}
+	}
+	sorted := floats.ParallelSort(4)
+	assert.True(t, sorted.IsSorted(), "floats should be sorted")
+	for i := 0; i < len(floats)/100; i++ {
+		assert.True(t, math.IsNaN(sorted[i]), "NaNs should be first")
+	}
+}
+
+const benchSortLen = 1 << 20
+
+func BenchmarkParallelSort(b *testing.B) {
+	ints := randomInts(benchSortLen)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ints.ParallelSort(0)
+	}
+}
+
+func BenchmarkSortInts(b *testing.B) {
+	ints := randomInts(benchSortLen)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c := append([]int(nil), ints...)
+		sort.Ints(c)
+	}
+}
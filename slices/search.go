@@ -0,0 +1,148 @@
+package slices
+
+import (
+	"sort"
+)
+
+// LowerBound func returns the index of the first element of c not lower than v, len(c) if there is none.
+// It is the index at which v would be inserted before the elements equal to it.
+// c must be sorted in ascending order, NaNs first, as by Sort.
+func LowerBound[S ~[]T, T Ordered](c S, v T) int {
+	return lowerBoundFunc(c, v, less[T])
+}
+
+// UpperBound func returns the index of the first element of c greater than v, len(c) if there is none.
+// It is the index at which v would be inserted after the elements equal to it.
+// c must be sorted in ascending order, NaNs first, as by Sort.
+func UpperBound[S ~[]T, T Ordered](c S, v T) int {
+	return upperBoundFunc(c, v, less[T])
+}
+
+// BinarySearch func returns the index of the first element of c equal to v and true,
+// or the index at which v would be inserted and false if c does not contain v.
+// c must be sorted in ascending order, NaNs first, as by Sort.
+func BinarySearch[S ~[]T, T Ordered](c S, v T) (int, bool) {
+	return binarySearchFunc(c, v, less[T])
+}
+
+// InsertSorted func creates a new slice with v inserted in c after the elements equal to it, so that it stays sorted.
+// Returns a slice of the type of c.
+// c must be sorted in ascending order, NaNs first, as by Sort.
+func InsertSorted[S ~[]T, T Ordered](c S, v T) S {
+	return insertSortedFunc(c, v, less[T])
+}
+
+// RangeBetween func returns the elements of c between lo and hi included.
+// The result is a sub-slice of c sharing its elements, like c[i:j].
+// c must be sorted in ascending order, NaNs first, as by Sort.
+func RangeBetween[S ~[]T, T Ordered](c S, lo, hi T) S {
+	return rangeBetweenFunc(c, lo, hi, less[T])
+}
+
+func lowerBoundFunc[T any](c []T, v T, less func(a, b T) bool) int {
+	return sort.Search(len(c), func(i int) bool {
+		return !less(c[i], v)
+	})
+}
+
+func upperBoundFunc[T any](c []T, v T, less func(a, b T) bool) int {
+	return sort.Search(len(c), func(i int) bool {
+		return less(v, c[i])
+	})
+}
+
+func binarySearchFunc[T any](c []T, v T, less func(a, b T) bool) (int, bool) {
+	i := lowerBoundFunc(c, v, less)
+	return i, i < len(c) && !less(v, c[i])
+}
+
+func insertSortedFunc[S ~[]T, T any](c S, v T, less func(a, b T) bool) S {
+	i := upperBoundFunc(c, v, less)
+	var ret = make(S, len(c)+1)
+	copy(ret, c[:i])
+	ret[i] = v
+	copy(ret[i+1:], c[i:])
+	return ret
+}
+
+func rangeBetweenFunc[S ~[]T, T any](c S, lo, hi T, less func(a, b T) bool) S {
+	i := lowerBoundFunc(c, lo, less)
+	j := upperBoundFunc(c, hi, less)
+	if j < i {
+		j = i
+	}
+	return c[i:j]
+}
+
+// SortedSlice is a slice of ordered elements which stays sorted in ascending order, NaNs first, as elements are inserted.
+// Its lookups are binary searches. The zero value is an empty SortedSlice ready to use.
+type SortedSlice[T Ordered] struct {
+	s Slice[T]
+}
+
+// NewSortedSlice returns a SortedSlice of the given values.
+func NewSortedSlice[T Ordered](values ...T) *SortedSlice[T] {
+	return &SortedSlice[T]{s: Sort(Slice[T](values))}
+}
+
+// Insert method inserts the values, each one after the elements equal to it.
+func (c *SortedSlice[T]) Insert(values ...T) {
+	for _, v := range values {
+		i := UpperBound(c.s, v)
+		var zero T
+		c.s = append(c.s, zero)
+		copy(c.s[i+1:], c.s[i:])
+		c.s[i] = v
+	}
+}
+
+// Remove method removes the first element equal to v, it returns false if there is none.
+func (c *SortedSlice[T]) Remove(v T) bool {
+	i, ok := BinarySearch(c.s, v)
+	if ok {
+		c.s = append(c.s[:i], c.s[i+1:]...)
+	}
+	return ok
+}
+
+// Contains method determines whether the slice includes a certain element by a binary search.
+func (c *SortedSlice[T]) Contains(v T) bool {
+	_, ok := BinarySearch(c.s, v)
+	return ok
+}
+
+// BinarySearch method returns the index of the first element equal to v and true,
+// or the index at which v would be inserted and false if the slice does not contain v.
+func (c *SortedSlice[T]) BinarySearch(v T) (int, bool) {
+	return BinarySearch(c.s, v)
+}
+
+// LowerBound method returns the index of the first element not lower than v, Len() if there is none.
+func (c *SortedSlice[T]) LowerBound(v T) int {
+	return LowerBound(c.s, v)
+}
+
+// UpperBound method returns the index of the first element greater than v, Len() if there is none.
+func (c *SortedSlice[T]) UpperBound(v T) int {
+	return UpperBound(c.s, v)
+}
+
+// RangeBetween method creates a new slice with the elements between lo and hi included.
+func (c *SortedSlice[T]) RangeBetween(lo, hi T) Slice[T] {
+	return append(make(Slice[T], 0), RangeBetween(c.s, lo, hi)...)
+}
+
+// At method returns the element at index i.
+func (c *SortedSlice[T]) At(i int) T {
+	return c.s[i]
+}
+
+// Len method returns the number of elements.
+func (c *SortedSlice[T]) Len() int {
+	return len(c.s)
+}
+
+// Slice method creates a new Slice with the elements in order.
+func (c *SortedSlice[T]) Slice() Slice[T] {
+	return append(make(Slice[T], 0, len(c.s)), c.s...)
+}
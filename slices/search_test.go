@@ -0,0 +1,124 @@
+package slices
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearch(t *testing.T) {
+	var ints = IntSlice{1, 3, 3, 3, 5, 8}
+
+	i, ok := ints.BinarySearch(3)
+	assert.True(t, ok, "3 should be found")
+	assert.Equal(t, 1, i, "the first 3 should be at index 1")
+	i, ok = ints.BinarySearch(4)
+	assert.False(t, ok, "4 should not be found")
+	assert.Equal(t, 4, i, "4 should be inserted at index 4")
+	_, ok = ints.BinarySearch(9)
+	assert.False(t, ok, "9 should not be found")
+
+	assert.Equal(t, 1, ints.LowerBound(3), "lower bound of 3 should be 1")
+	assert.Equal(t, 4, ints.UpperBound(3), "upper bound of 3 should be 4")
+	assert.Equal(t, 0, ints.LowerBound(0), "lower bound of 0 should be 0")
+	assert.Equal(t, 6, ints.UpperBound(8), "upper bound of 8 should be len")
+
+	assert.Equal(t, IntSlice{1, 3, 3, 3, 4, 5, 8}, ints.InsertSorted(4), "4 should be inserted in order")
+	assert.Equal(t, IntSlice{0, 1, 3, 3, 3, 5, 8}, ints.InsertSorted(0), "0 should be inserted first")
+	assert.Equal(t, IntSlice{1, 3, 3, 3, 5, 8}, ints, "the original slice should be left unchanged")
+
+	assert.Equal(t, IntSlice{3, 3, 3, 5}, ints.RangeBetween(2, 5), "range should include both bounds")
+	assert.Empty(t, ints.RangeBetween(6, 7), "range with no element should be empty")
+	assert.Empty(t, ints.RangeBetween(5, 1), "range with lo > hi should be empty")
+
+	var strs = StringSlice{"bar", "baz", "foo"}
+	_, ok = strs.BinarySearch("baz")
+	assert.True(t, ok, "baz should be found")
+	assert.Equal(t, StringSlice{"bar", "baz", "bat", "foo"}.Sort(), strs.InsertSorted("bat"), "bat should be inserted in order")
+	assert.Equal(t, StringSlice{"baz", "foo"}, strs.RangeBetween("bat", "foo"), "range should select strings")
+	assert.Equal(t, 2, strs.LowerBound("c"), "lower bound of c should be 2")
+	assert.Equal(t, 3, strs.UpperBound("foo"), "upper bound of foo should be 3")
+
+	var floats = Float64Slice{math.NaN(), 1, 2.5}
+	i, ok = floats.BinarySearch(math.NaN())
+	assert.True(t, ok, "NaN should be found")
+	assert.Equal(t, 0, i, "NaN should be first")
+	assert.Equal(t, 2, floats.LowerBound(2), "lower bound of 2 should be 2")
+	assert.Equal(t, 2, floats.UpperBound(1), "upper bound of 1 should be 2")
+	assert.Equal(t, Float64Slice{1, 2.5}, floats.RangeBetween(0, 3), "range should not include NaN")
+	assert.Len(t, floats.InsertSorted(2), 4, "2 should be inserted")
+
+	var floats32 = Float32Slice{1, 2}
+	_, ok = floats32.BinarySearch(2)
+	assert.True(t, ok, "2 should be found")
+	assert.Equal(t, Float32Slice{1, 1.5, 2}, floats32.InsertSorted(1.5), "1.5 should be inserted in order")
+	assert.Equal(t, Float32Slice{2}, floats32.RangeBetween(1.5, 2), "range should select float32")
+	assert.Equal(t, 1, floats32.LowerBound(1.5), "lower bound of 1.5 should be 1")
+	assert.Equal(t, 2, floats32.UpperBound(2), "upper bound of 2 should be 2")
+
+	var intfs = InterfaceSlice{nil, 1, 2.5, "a"}
+	_, ok = intfs.BinarySearch(2.5)
+	assert.True(t, ok, "2.5 should be found")
+	assert.Equal(t, InterfaceSlice{nil, 1, 2, 2.5, "a"}, intfs.InsertSorted(2), "2 should be inserted between numbers")
+	assert.Equal(t, InterfaceSlice{1, 2.5}, intfs.RangeBetween(0, 10), "range should select numbers")
+	assert.Equal(t, 3, intfs.LowerBound("0"), "lower bound of a string should be after numbers")
+	assert.Equal(t, 1, intfs.UpperBound(nil), "upper bound of nil should be 1")
+
+	// numbers of other types and other values are ordered as v but are not equal to it
+	i, ok = InterfaceSlice{1, 2}.BinarySearch(1.0)
+	assert.False(t, ok, "1.0 should not be found among ints")
+	assert.Equal(t, 0, i, "1.0 would be inserted before 1")
+	i, ok = InterfaceSlice{1, 1.0, int64(1)}.BinarySearch(int64(1))
+	assert.True(t, ok, "int64(1) should be found")
+	assert.Equal(t, 2, i, "index should be the one of int64(1)")
+	_, ok = InterfaceSlice{[]int{1}}.BinarySearch([]int{9})
+	assert.False(t, ok, "[]int{9} should not be found")
+	_, ok = InterfaceSlice{[]int{1}}.BinarySearch([]int{1, 2})
+	assert.False(t, ok, "a slice should not be found from its prefix")
+	_, ok = InterfaceSlice{[]int{1, 2}}.BinarySearch([]int{1})
+	assert.False(t, ok, "a prefix should not be found from a longer slice")
+	i, ok = InterfaceSlice{"a", []int{1}, []int{9}, struct{}{}}.BinarySearch([]int{9})
+	assert.True(t, ok, "[]int{9} should be found among other values")
+	assert.Equal(t, 2, i, "index should be the one of []int{9}")
+	_, ok = InterfaceSlice{"a", []int{1}, struct{}{}}.BinarySearch(struct{}{})
+	assert.True(t, ok, "struct{}{} should be found with ==")
+	for _, v := range []interface{}{1.0, []int{9}, [1]int{1}} {
+		_, ok = InterfaceSlice{1, 2, []int{1}}.BinarySearch(v)
+		assert.Equal(t, InterfaceSlice{1, 2, []int{1}}.Contains(v), ok, "BinarySearch should agree with Contains on %v", v)
+	}
+
+	assert.Equal(t, 2, LowerBound(Slice[int]{1, 2, 3}, 3), "LowerBound func should search a generic slice")
+	_, ok = BinarySearch(Slice[string]{"a"}, "a")
+	assert.True(t, ok, "BinarySearch func should search a generic slice")
+}
+
+func TestSortedSlice(t *testing.T) {
+	s := NewSortedSlice(5, 1, 3)
+	assert.Equal(t, Slice[int]{1, 3, 5}, s.Slice(), "values should be sorted")
+
+	s.Insert(4, 0, 3)
+	assert.Equal(t, Slice[int]{0, 1, 3, 3, 4, 5}, s.Slice(), "inserted values should keep the slice sorted")
+	assert.Equal(t, 6, s.Len(), "len should be 6")
+	assert.Equal(t, 4, s.At(4), "element 4 should be 4")
+	assert.True(t, s.Contains(4), "s should contain 4")
+	assert.False(t, s.Contains(2), "s should not contain 2")
+	i, ok := s.BinarySearch(3)
+	assert.True(t, ok, "3 should be found")
+	assert.Equal(t, 2, i, "the first 3 should be at index 2")
+	assert.Equal(t, 2, s.LowerBound(3), "lower bound of 3 should be 2")
+	assert.Equal(t, 4, s.UpperBound(3), "upper bound of 3 should be 4")
+
+	r := s.RangeBetween(1, 3)
+	assert.Equal(t, Slice[int]{1, 3, 3}, r, "range should include both bounds")
+	r[0] = 100
+	assert.Equal(t, 1, s.At(1), "the range should be a copy")
+
+	assert.True(t, s.Remove(3), "3 should be removed")
+	assert.False(t, s.Remove(2), "2 should not be removed")
+	assert.Equal(t, Slice[int]{0, 1, 3, 4, 5}, s.Slice(), "only one 3 should be removed")
+
+	var zero SortedSlice[string]
+	zero.Insert("b", "a")
+	assert.Equal(t, Slice[string]{"a", "b"}, zero.Slice(), "the zero value should be ready to use")
+}
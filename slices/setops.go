@@ -0,0 +1,238 @@
+package slices
+
+import (
+	"math"
+	"reflect"
+)
+
+// smallSetLen is the number of elements up to which set operations look elements up by linear scans,
+// beyond it they use a map.
+const smallSetLen = 16
+
+// lookup records elements to look them up, by a linear scan while they are few and in a map beyond.
+// NaNs are all equal to each other, as in Sort.
+type lookup[T comparable] struct {
+	list []T
+	m    map[T]struct{}
+	// float tells whether T is a float type, whose NaNs are recorded by nan
+	float bool
+	nan   bool
+}
+
+func newLookup[T comparable](c []T) *lookup[T] {
+	k := reflect.TypeOf((*T)(nil)).Elem().Kind()
+	l := &lookup[T]{float: k == reflect.Float32 || k == reflect.Float64}
+	for _, v := range c {
+		l.add(v)
+	}
+	return l
+}
+
+func (l *lookup[T]) add(v T) {
+	if l.float && v != v {
+		l.nan = true
+		return
+	}
+	if l.m != nil {
+		l.m[v] = struct{}{}
+		return
+	}
+	l.list = append(l.list, v)
+	if len(l.list) > smallSetLen {
+		l.m = make(map[T]struct{}, 2*len(l.list))
+		for _, v := range l.list {
+			l.m[v] = struct{}{}
+		}
+		l.list = nil
+	}
+}
+
+func (l *lookup[T]) has(v T) bool {
+	if l.float && v != v {
+		return l.nan
+	}
+	if l.m != nil {
+		_, ok := l.m[v]
+		return ok
+	}
+	for _, w := range l.list {
+		if w == v {
+			return true
+		}
+	}
+	return false
+}
+
+// intfLookup is the lookup of an InterfaceSlice: hashable values are looked up in a map,
+// slices, arrays and other values by a linear scan with equalIntf. NaNs are all equal to each other.
+type intfLookup struct {
+	others []interface{}
+	m      map[interface{}]struct{}
+	// nans holds the float types of the NaNs recorded
+	nans map[reflect.Type]struct{}
+}
+
+func newIntfLookup(c []interface{}) *intfLookup {
+	l := &intfLookup{m: make(map[interface{}]struct{})}
+	for _, v := range c {
+		l.add(v)
+	}
+	return l
+}
+
+// hashable reports whether v can be a map key: its dynamic type is comparable
+// and it holds no slice, map or func, in its fields or in the interfaces they hold.
+// Arrays are not looked up in a map, they are compared element by element.
+func hashable(v interface{}) bool {
+	val := reflect.ValueOf(v)
+	return val.Kind() != reflect.Array && hashableValue(val)
+}
+
+func hashableValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Func:
+		return false
+	case reflect.Interface:
+		return v.IsNil() || hashableValue(v.Elem())
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if !hashableValue(v.Index(i)) {
+				return false
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if !hashableValue(v.Field(i)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// nanType returns the type of v and true if v is a float NaN.
+func nanType(v interface{}) (reflect.Type, bool) {
+	val := reflect.ValueOf(v)
+	switch val.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return val.Type(), math.IsNaN(val.Float())
+	}
+	return nil, false
+}
+
+func (l *intfLookup) add(v interface{}) {
+	if t, ok := nanType(v); ok {
+		if l.nans == nil {
+			l.nans = make(map[reflect.Type]struct{})
+		}
+		l.nans[t] = struct{}{}
+		return
+	}
+	if hashable(v) {
+		l.m[v] = struct{}{}
+		return
+	}
+	l.others = append(l.others, v)
+}
+
+func (l *intfLookup) has(v interface{}) bool {
+	if t, ok := nanType(v); ok {
+		_, ok = l.nans[t]
+		return ok
+	}
+	if hashable(v) {
+		_, ok := l.m[v]
+		return ok
+	}
+	return equalIntf(l.others, v)
+}
+
+// equalIntf reports whether others contains v, as by equalElement.
+func equalIntf(others []interface{}, v interface{}) bool {
+	for _, w := range others {
+		if equalElement(w, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// setOp appends to ret the elements of c for which keep returns true, skipping the ones already in seen.
+func setOp[S ~[]T, T any](ret S, c S, seen interface {
+	has(T) bool
+	add(T)
+}, keep func(T) bool) S {
+	for _, v := range c {
+		if keep(v) && !seen.has(v) {
+			seen.add(v)
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
+
+func all[T any](T) bool {
+	return true
+}
+
+// Union func creates a new slice with the elements of a then the ones of b, without duplicates, in their order.
+// Returns a slice of the type of a.
+func Union[S ~[]T, T comparable](a, b S) S {
+	seen := newLookup[T](nil)
+	return setOp[S, T](setOp[S, T](make(S, 0), a, seen, all[T]), b, seen, all[T])
+}
+
+// Intersect func creates a new slice with the elements of a which are in b, without duplicates, in their order.
+// Returns a slice of the type of a.
+func Intersect[S ~[]T, T comparable](a, b S) S {
+	return setOp[S, T](make(S, 0), a, newLookup[T](nil), newLookup(b).has)
+}
+
+// Difference func creates a new slice with the elements of a which are not in b, without duplicates, in their order.
+// Returns a slice of the type of a.
+func Difference[S ~[]T, T comparable](a, b S) S {
+	inB := newLookup(b)
+	return setOp[S, T](make(S, 0), a, newLookup[T](nil), func(v T) bool {
+		return !inB.has(v)
+	})
+}
+
+// SymmetricDifference func creates a new slice with the elements of a which are not in b then the ones of b which are not in a,
+// without duplicates, in their order. Returns a slice of the type of a.
+func SymmetricDifference[S ~[]T, T comparable](a, b S) S {
+	inA, inB := newLookup(a), newLookup(b)
+	seen := newLookup[T](nil)
+	ret := setOp[S, T](make(S, 0), a, seen, func(v T) bool {
+		return !inB.has(v)
+	})
+	return setOp[S, T](ret, b, seen, func(v T) bool {
+		return !inA.has(v)
+	})
+}
+
+func unionIntf(a, b InterfaceSlice) InterfaceSlice {
+	seen := newIntfLookup(nil)
+	return setOp[InterfaceSlice, interface{}](setOp[InterfaceSlice, interface{}](make(InterfaceSlice, 0), a, seen, all[interface{}]), b, seen, all[interface{}])
+}
+
+func intersectIntf(a, b InterfaceSlice) InterfaceSlice {
+	return setOp[InterfaceSlice, interface{}](make(InterfaceSlice, 0), a, newIntfLookup(nil), newIntfLookup(b).has)
+}
+
+func differenceIntf(a, b InterfaceSlice) InterfaceSlice {
+	inB := newIntfLookup(b)
+	return setOp[InterfaceSlice, interface{}](make(InterfaceSlice, 0), a, newIntfLookup(nil), func(v interface{}) bool {
+		return !inB.has(v)
+	})
+}
+
+func symmetricDifferenceIntf(a, b InterfaceSlice) InterfaceSlice {
+	inA, inB := newIntfLookup(a), newIntfLookup(b)
+	seen := newIntfLookup(nil)
+	ret := setOp[InterfaceSlice, interface{}](make(InterfaceSlice, 0), a, seen, func(v interface{}) bool {
+		return !inB.has(v)
+	})
+	return setOp[InterfaceSlice, interface{}](ret, b, seen, func(v interface{}) bool {
+		return !inA.has(v)
+	})
+}
@@ -0,0 +1,124 @@
+package slices
+
+import (
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetOps(t *testing.T) {
+	var a = StringSlice{"a", "b", "b", "c"}
+	var b = StringSlice{"c", "d", "a", "d"}
+
+	assert.Equal(t, StringSlice{"a", "b", "c", "d"}, a.Union(b), "union should keep the order and drop duplicates")
+	assert.Equal(t, StringSlice{"a", "c"}, a.Intersect(b), "intersect should keep the elements of a in b")
+	assert.Equal(t, StringSlice{"b"}, a.Difference(b), "difference should keep the elements of a not in b")
+	assert.Equal(t, StringSlice{"b", "d"}, a.SymmetricDifference(b), "symmetric difference should keep the elements in only one slice")
+	assert.Equal(t, StringSlice{}, a.Intersect(nil), "intersect with nil should be empty")
+	assert.Equal(t, StringSlice{"a", "b", "c"}, a.Difference(nil), "difference with nil should dedup a")
+
+	assert.Equal(t, IntSlice{1, 2, 3}, IntSlice{1, 2}.Union(IntSlice{2, 3}), "int union")
+	assert.Equal(t, IntSlice{2}, IntSlice{1, 2}.Intersect(IntSlice{2, 3}), "int intersect")
+	assert.Equal(t, IntSlice{1}, IntSlice{1, 2}.Difference(IntSlice{2, 3}), "int difference")
+	assert.Equal(t, IntSlice{1, 3}, IntSlice{1, 2}.SymmetricDifference(IntSlice{2, 3}), "int symmetric difference")
+	assert.Equal(t, Float64Slice{1.5, 2}, Float64Slice{1.5}.Union(Float64Slice{2, 1.5}), "float64 union")
+	assert.Equal(t, Float64Slice{1.5}, Float64Slice{1.5, 2}.Intersect(Float64Slice{1.5}), "float64 intersect")
+	assert.Equal(t, Float64Slice{2}, Float64Slice{1.5, 2}.Difference(Float64Slice{1.5}), "float64 difference")
+	assert.Equal(t, Float64Slice{2, 3}, Float64Slice{1.5, 2}.SymmetricDifference(Float64Slice{1.5, 3}), "float64 symmetric difference")
+	assert.Equal(t, Float32Slice{1.5, 2}, Float32Slice{1.5}.Union(Float32Slice{2}), "float32 union")
+	assert.Equal(t, Float32Slice{}, Float32Slice{1.5}.Intersect(Float32Slice{2}), "float32 intersect")
+	assert.Equal(t, Float32Slice{1.5}, Float32Slice{1.5}.Difference(Float32Slice{2}), "float32 difference")
+	assert.Equal(t, Float32Slice{1.5, 2}, Float32Slice{1.5}.SymmetricDifference(Float32Slice{2}), "float32 symmetric difference")
+	assert.Equal(t, Slice[int]{1, 2}, Slice[int]{1}.Union(Slice[int]{2}), "generic union")
+	assert.Equal(t, Slice[int]{1}, Slice[int]{1, 2}.Intersect(Slice[int]{1}), "generic intersect")
+	assert.Equal(t, Slice[int]{2}, Slice[int]{1, 2}.Difference(Slice[int]{1}), "generic difference")
+	assert.Equal(t, Slice[int]{2, 3}, Slice[int]{1, 2}.SymmetricDifference(Slice[int]{1, 3}), "generic symmetric difference")
+
+	// large inputs go through maps
+	var large, odd IntSlice
+	for i := 0; i < 1000; i++ {
+		large = append(large, i%500)
+		if i%2 == 1 {
+			odd = append(odd, i)
+		}
+	}
+	assert.Len(t, large.Union(odd), 750, "union should have 500 + 250 elements")
+	assert.Len(t, large.Intersect(odd), 250, "intersect should have the 250 odd numbers below 500")
+	assert.Len(t, large.Difference(odd), 250, "difference should have the 250 even numbers below 500")
+	sym := large.SymmetricDifference(odd)
+	assert.Len(t, sym, 500, "symmetric difference should have 250 even and 250 odd numbers")
+	assert.Equal(t, 0, sym[0], "order should be kept")
+	assert.Equal(t, 501, sym[250], "elements of b should follow the ones of a")
+
+	var ids = make(StringSlice, 100)
+	for i := range ids {
+		ids[i] = strconv.Itoa(i)
+	}
+	assert.Equal(t, StringSlice{"98", "99"}, ids.Difference(ids[:98]), "difference should find the ids not in b")
+}
+
+func TestSetOpsInterface(t *testing.T) {
+	var a = InterfaceSlice{1, "a", []int{1, 2}, []int{1, 2}, [2]int{3, 4}, map[string]int{"a": 1}, nil}
+	var b = InterfaceSlice{"a", []int{1}, []int{1, 2}, [2]int{3, 4}, map[string]int{"a": 1}, 2}
+
+	assert.Equal(t, InterfaceSlice{1, "a", []int{1, 2}, [2]int{3, 4}, map[string]int{"a": 1}, nil, []int{1}, 2}, a.Union(b), "union should compare slices element by element")
+	assert.Equal(t, InterfaceSlice{"a", []int{1, 2}, [2]int{3, 4}, map[string]int{"a": 1}}, a.Intersect(b), "intersect should compare slices element by element")
+	assert.Equal(t, InterfaceSlice{1, nil}, a.Difference(b), "a prefix should not be equal to a slice")
+	assert.Equal(t, InterfaceSlice{1, nil, []int{1}, 2}, a.SymmetricDifference(b), "symmetric difference should compare slices element by element")
+}
+
+func TestSetOpsAsContains(t *testing.T) {
+	type S struct {
+		X int
+	}
+	values := []interface{}{
+		[]int{1}, []int{1, 2}, []interface{}{1}, [1]int{1},
+		map[string]int{"a": 1}, map[string]int{"a": 2},
+		&S{X: 1}, &S{X: 1}, S{X: 1}, 1, 1.0, math.NaN(), nil,
+	}
+	for _, a := range values {
+		for _, b := range values {
+			c := InterfaceSlice{a}
+			found := len(c.Intersect(InterfaceSlice{b})) == 1
+			assert.Equal(t, c.Contains(b), found, "Intersect should agree with Contains on %v and %v", a, b)
+			assert.Equal(t, found, len(c.Difference(InterfaceSlice{b})) == 0, "Difference should agree with Contains on %v and %v", a, b)
+		}
+	}
+	assert.False(t, InterfaceSlice{[]int{1}}.Contains([]int{1, 2}), "a prefix should not be equal to a slice")
+	assert.True(t, InterfaceSlice{map[string]int{"a": 1}}.Contains(map[string]int{"a": 1}), "maps should be deeply compared")
+	assert.False(t, InterfaceSlice{&S{X: 1}}.Contains(&S{X: 1}), "distinct pointers should not be equal")
+}
+
+func TestSetOpsUnhashable(t *testing.T) {
+	type S struct {
+		X interface{}
+	}
+	var a = InterfaceSlice{S{X: []int{1}}, S{X: 1}, S{X: []int{1}}}
+	var b = InterfaceSlice{S{X: []int{1}}, S{X: map[string]int{}}, [1]interface{}{[]int{2}}}
+
+	assert.Equal(t, InterfaceSlice{S{X: []int{1}}, S{X: 1}, S{X: map[string]int{}}, [1]interface{}{[]int{2}}}, a.Union(b), "structs holding slices or maps should be compared without a map")
+	assert.Equal(t, InterfaceSlice{S{X: []int{1}}}, a.Intersect(b), "intersect should find structs holding slices")
+	assert.Equal(t, InterfaceSlice{S{X: 1}}, a.Difference(b), "difference should find structs holding slices")
+	assert.Equal(t, InterfaceSlice{S{X: 1}, S{X: map[string]int{}}, [1]interface{}{[]int{2}}}, a.SymmetricDifference(b), "symmetric difference should find structs holding slices")
+}
+
+func TestSetOpsNaN(t *testing.T) {
+	nan := math.NaN()
+	union := Float64Slice{nan, 1, nan}.Union(Float64Slice{nan, 2})
+	assert.Len(t, union, 3, "NaNs should be equal to each other")
+	assert.True(t, math.IsNaN(union[0]), "the NaN should be kept in its order")
+	assert.Equal(t, Float64Slice{1}, Float64Slice{nan, 1}.Difference(Float64Slice{nan}), "a NaN should be found among NaNs")
+	assert.Len(t, Float32Slice{float32(nan)}.Intersect(Float32Slice{float32(nan)}), 1, "float32 NaNs should be equal to each other")
+
+	var large Float64Slice
+	for i := 0; i < 100; i++ {
+		large = append(large, float64(i%50), nan)
+	}
+	assert.Len(t, large.Union(nil), 51, "NaNs should be equal to each other beyond the linear scan")
+
+	intfs := InterfaceSlice{nan, 1, nan, float32(nan)}.Union(InterfaceSlice{nan})
+	assert.Len(t, intfs, 3, "NaNs of the same type should be equal to each other")
+	assert.Equal(t, InterfaceSlice{1}, InterfaceSlice{nan, 1}.Difference(InterfaceSlice{nan}), "a NaN should be found among NaNs")
+}
@@ -0,0 +1,185 @@
+package slices
+
+import (
+	"context"
+
+	"github.com/francoispqt/lists"
+)
+
+// Slice is a generic custom type for a slice of comparable elements.
+// For element types which are not comparable, see AnySlice.
+type Slice[T comparable] []T
+
+// Contains method determines whether a slice includes a certain element, returning true or false as appropriate.
+func (c Slice[T]) Contains(s T) bool {
+	for _, v := range c {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// ForEach method executes a provided func once for each slice element.
+func (c Slice[T]) ForEach(cb func(int, T)) {
+	AnySlice[T](c).ForEach(cb)
+}
+
+// Map method creates a new slice with the results of calling a provided func on every element in the calling array.
+// Returns a slice of the original type.
+// For asynchronicity, see MapAsync.
+func (c Slice[T]) Map(cb func(int, T) T) Slice[T] {
+	return Slice[T](AnySlice[T](c).Map(cb))
+}
+
+// MapInterface method creates a new slice with the results of calling a provided func on every element in the calling array.
+// Returns a slice of interfaces.
+// For asynchronicity, see MapAsyncInterface.
+func (c Slice[T]) MapInterface(cb func(int, T) interface{}) InterfaceSlice {
+	return AnySlice[T](c).MapInterface(cb)
+}
+
+// MapAsync method creates a new slice with the results of calling a provided go routine on every element in the calling array.
+// The go routine must write a lists.Result to the chan, its Key being the index of the element.
+// An optional max concurrency can be passed as second argument, 0 means no limit.
+// Returns a slice of the original type.
+// If you want to map to a slice of different type, see MapAsyncInterface.
+func (c Slice[T]) MapAsync(cb func(int, T, chan<- lists.Result[int, T]), maxConcurrency ...int) Slice[T] {
+	return Slice[T](AnySlice[T](c).MapAsync(cb, maxConcurrency...))
+}
+
+// MapAsyncContext method is MapAsync with a context given to every go routine.
+// When the context is done, no more go routine is started and MapAsyncContext returns ctx.Err() with the results received so far.
+func (c Slice[T]) MapAsyncContext(ctx context.Context, cb func(context.Context, int, T, chan<- lists.Result[int, T]), opts lists.Options) (Slice[T], error) {
+	return MapAsyncToContext(ctx, c, cb, opts)
+}
+
+// MapAsyncStream method calls a provided go routine on every element in the calling array and returns a chan yielding the results as soon as they are written.
+// With opts.Ordered, results are yielded in the order of the elements.
+// The chan is closed once every go routine is done, the error of the call (nil on success) is then written to the error chan.
+func (c Slice[T]) MapAsyncStream(ctx context.Context, cb func(context.Context, int, T, chan<- lists.Result[int, T]), opts lists.Options) (<-chan lists.Result[int, T], <-chan error) {
+	return AnySlice[T](c).MapAsyncStream(ctx, cb, opts)
+}
+
+// MapAsyncErr method creates a new slice with the values returned by calling a provided func as a go routine on every element in the calling array.
+// Errors returned by the func are collected as set by opts.ErrorMode and wrapped in a *lists.ElementError, elements which failed are left to their zero value.
+// The context is given to every go routine, when it is done no more go routine is started.
+func (c Slice[T]) MapAsyncErr(ctx context.Context, cb func(context.Context, int, T) (T, error), opts lists.Options) (Slice[T], error) {
+	return MapAsyncErrTo(ctx, c, cb, opts)
+}
+
+// MapAsyncRetry method is MapAsyncErr returning the lists.Outcome of every element, with its number of attempts.
+// Failed elements are retried as set by opts.Retry.
+func (c Slice[T]) MapAsyncRetry(ctx context.Context, cb func(context.Context, int, T) (T, error), opts lists.Options) ([]lists.Outcome[T], error) {
+	return AnySlice[T](c).MapAsyncRetry(ctx, cb, opts)
+}
+
+// MapParallel method creates a new slice with the values returned by calling a provided func as a go routine on every element in the calling array.
+// No more than workers go routines run at the same time, 0 means no limit.
+// Returns a slice of the original type.
+func (c Slice[T]) MapParallel(cb func(int, T) T, workers int) Slice[T] {
+	return MapParallelTo(c, cb, workers)
+}
+
+// MapAsyncInterface method creates a new slice with the results of calling a provided go routine on every element in the calling array.
+// The go routine must write a lists.Result to the chan, its Key being the index of the element.
+// Returns InterfaceSlice.
+// If you know the result will be of original type, use MapAsync.
+func (c Slice[T]) MapAsyncInterface(cb func(int, T, chan<- lists.Result[int, interface{}]), maxConcurrency ...int) InterfaceSlice {
+	return AnySlice[T](c).MapAsyncInterface(cb, maxConcurrency...)
+}
+
+// MapAsyncInterfaceContext method is MapAsyncInterface with a context given to every go routine.
+// When the context is done, no more go routine is started and MapAsyncInterfaceContext returns ctx.Err() with the results received so far.
+func (c Slice[T]) MapAsyncInterfaceContext(ctx context.Context, cb func(context.Context, int, T, chan<- lists.Result[int, interface{}]), opts lists.Options) (InterfaceSlice, error) {
+	return AnySlice[T](c).MapAsyncInterfaceContext(ctx, cb, opts)
+}
+
+// Reduce method applies a func against an accumulator and each element in the slice (from left to right) to reduce it to a single value of the original type.
+// To reduce to a value of any other type, see the Reduce func.
+// For asynchronicity, see ReduceAsync.
+func (c Slice[T]) Reduce(cb func(int, T, T) T, agg T) T {
+	return Reduce(c, cb, agg)
+}
+
+// ReduceAsync method applies a go routine against an accumulator and each element in the slice (from left to right) to reduce it to a single value of the original type.
+// You must read the aggregator from the lists.Aggregator.Agg channel before writing to the lists.Aggregator.Done channel.
+// To reduce to a value of any other type, see the ReduceAsync func.
+// For synchronicity, see Reduce.
+func (c Slice[T]) ReduceAsync(cb func(int, T, *lists.Aggregator[T]), agg T) T {
+	return ReduceAsync(c, cb, agg)
+}
+
+// ReduceAsyncContext method is ReduceAsync with a context given to every go routine.
+// When the context is done, no more go routine is started and the current state of the accumulator is returned with ctx.Err().
+// Panics are recovered as set by opts.OnPanic, the state of the accumulator is left unchanged by a go routine which panicked.
+func (c Slice[T]) ReduceAsyncContext(ctx context.Context, cb func(context.Context, int, T, *lists.Aggregator[T]), agg T, opts lists.Options) (T, error) {
+	return ReduceAsyncContext(ctx, c, cb, agg, opts)
+}
+
+// ReduceParallel method combines the elements of the slice to a single value of the original type, using workers go routines.
+// The slice is split into one chunk per worker, each chunk is reduced from identity, then the partial results are combined in a tree.
+// combine must be associative (combine(combine(a, b), c) == combine(a, combine(b, c))) and identity must leave any value unchanged when combined with it,
+// elements are always combined in their order so combine needs not be commutative.
+// If workers is not higher than 0, runtime.GOMAXPROCS(0) workers are used.
+// For a sequential reduction, see Reduce.
+func (c Slice[T]) ReduceParallel(identity T, combine func(T, T) T, workers int) T {
+	return AnySlice[T](c).ReduceParallel(identity, combine, workers)
+}
+
+// IsLast checks if the index passed is the last of the slice
+func (c Slice[T]) IsLast(i int) bool {
+	return i == len(c)-1
+}
+
+// Indexes returns a slice of ints with including the indexes of the slice
+func (c Slice[T]) Indexes() []int {
+	return AnySlice[T](c).Indexes()
+}
+
+// Filter method creates a new slice with all elements that pass the test implemented by the provided function.
+func (c Slice[T]) Filter(cb func(k int, v T) bool) Slice[T] {
+	return Slice[T](AnySlice[T](c).Filter(cb))
+}
+
+// SortStable method creates a new slice with the elements sorted by the provided less func, equal elements keeping their order.
+// Returns a slice of the original type. For ordered element types, see the Sort and SortDesc funcs, to sort by a key, see SortBy.
+func (c Slice[T]) SortStable(less func(a, b T) bool) Slice[T] {
+	return SortStable(c, less)
+}
+
+// Union method creates a new slice with the elements of c then the ones of s, without duplicates, in their order.
+// Returns a slice of the original type.
+func (c Slice[T]) Union(s Slice[T]) Slice[T] {
+	return Union(c, s)
+}
+
+// Intersect method creates a new slice with the elements of c which are in s, without duplicates, in their order.
+// Returns a slice of the original type.
+func (c Slice[T]) Intersect(s Slice[T]) Slice[T] {
+	return Intersect(c, s)
+}
+
+// Difference method creates a new slice with the elements of c which are not in s, without duplicates, in their order.
+// Returns a slice of the original type.
+func (c Slice[T]) Difference(s Slice[T]) Slice[T] {
+	return Difference(c, s)
+}
+
+// SymmetricDifference method creates a new slice with the elements of c which are not in s then the ones of s which are not in c,
+// without duplicates, in their order.
+// Returns a slice of the original type.
+func (c Slice[T]) SymmetricDifference(s Slice[T]) Slice[T] {
+	return SymmetricDifference(c, s)
+}
+
+// ToChan method returns a chan yielding the elements of the slice in order, with a buffer of the given size.
+// The chan is closed once every element is written or ctx is done.
+func (c Slice[T]) ToChan(ctx context.Context, buffer int) <-chan T {
+	return AnySlice[T](c).ToChan(ctx, buffer)
+}
+
+// Cast explicitly cast the Slice to a []T type
+func (c Slice[T]) Cast() []T {
+	return c
+}
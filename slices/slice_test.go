@@ -0,0 +1,434 @@
+package slices
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/francoispqt/lists"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlice(t *testing.T) {
+	var test = Slice[int]{1, 2, 3}
+
+	assert.Equal(t, []int{0, 1, 2}, test.Indexes(), "indexes should contain the indexes")
+	assert.True(t, test.Contains(2), "test should contain 2")
+	assert.False(t, test.Contains(4), "test should not contain 4")
+
+	forEachT := 0
+	test.ForEach(func(k int, v int) {
+		forEachT += v
+	})
+	assert.Equal(t, 6, forEachT, "foreach should have updated forEachT to be 6")
+
+	mapped := test.Map(func(k int, v int) int {
+		return v * 2
+	})
+	assert.Equal(t, Slice[int]{2, 4, 6}, mapped, "map should double every element")
+
+	mappedIntf := test.MapInterface(func(k int, v int) interface{} {
+		return strconv.Itoa(v)
+	})
+	assert.Equal(t, InterfaceSlice{"1", "2", "3"}, mappedIntf, "map interface should convert every element")
+
+	sum := test.Reduce(func(k int, v int, agg int) int {
+		return agg + v
+	}, 0)
+	assert.Equal(t, 6, sum, "sum should be 6")
+
+	str := Reduce(test, func(k int, v int, agg string) string {
+		return agg + strconv.Itoa(v)
+	}, "")
+	assert.Equal(t, "123", str, "reduce func should reduce to a string")
+
+	filtered := test.Filter(func(k int, v int) bool {
+		return v > 1
+	})
+	assert.Equal(t, Slice[int]{2, 3}, filtered, "filter should remove 1")
+	assert.True(t, filtered.IsLast(1), "1 should be last index")
+	assert.IsType(t, []int{}, filtered.Cast(), "cast should give original type")
+}
+
+func TestSliceAsync(t *testing.T) {
+	var test = Slice[string]{"hello", "foo", "bar"}
+
+	for _, maxConc := range []int{0, 1, 2, 10} {
+		ret := test.MapAsync(func(k int, v string, done chan<- lists.Result[int, string]) {
+			if k == 0 {
+				time.Sleep(10 * time.Millisecond)
+			}
+			done <- lists.Result[int, string]{Key: k, Value: v + " !"}
+		}, maxConc)
+		assert.Equal(t, Slice[string]{"hello !", "foo !", "bar !"}, ret, "map async should map back to original index")
+
+		retIntf := test.MapAsyncInterface(func(k int, v string, done chan<- lists.Result[int, interface{}]) {
+			go func() {
+				done <- lists.Result[int, interface{}]{Key: k, Value: len(v)}
+			}()
+		}, maxConc)
+		assert.Equal(t, InterfaceSlice{5, 3, 3}, retIntf, "map async interface should map back to original index")
+	}
+
+	empty := Slice[string]{}.MapAsync(func(k int, v string, done chan<- lists.Result[int, string]) {
+		done <- lists.Result[int, string]{Key: k, Value: v}
+	}, 2)
+	assert.Len(t, empty, 0, "map async on empty slice should return an empty slice")
+
+	joined := test.ReduceAsync(func(k int, v string, agg *lists.Aggregator[string]) {
+		agg.Done <- <-agg.Agg + v
+	}, "")
+	assert.Equal(t, "hellofoobar", joined, "reduce async should run in series")
+
+	count := ReduceAsync(test, func(k int, v string, agg *lists.Aggregator[int]) {
+		agg.Done <- <-agg.Agg + len(v)
+	}, 0)
+	assert.Equal(t, 11, count, "reduce async func should reduce to an int")
+}
+
+func TestSliceAsyncContext(t *testing.T) {
+	var test = Slice[int]{1, 2, 3}
+
+	ret, err := test.MapAsyncContext(context.Background(), func(ctx context.Context, k int, v int, done chan<- lists.Result[int, int]) {
+		done <- lists.Result[int, int]{Key: k, Value: v * 10}
+	}, lists.Options{MaxConcurrency: 2})
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, Slice[int]{10, 20, 30}, ret, "map async context should map back to original index")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = test.MapAsyncContext(ctx, func(ctx context.Context, k int, v int, done chan<- lists.Result[int, int]) {
+		if k == 1 {
+			<-ctx.Done()
+			return
+		}
+		done <- lists.Result[int, int]{Key: k, Value: v}
+	}, lists.Options{})
+	assert.Equal(t, context.DeadlineExceeded, err, "err should be context.DeadlineExceeded")
+
+	legacy, err := StringSlice{"a", "b"}.MapAsyncContext(context.Background(), func(ctx context.Context, k int, v string, done chan [2]interface{}) {
+		done <- [2]interface{}{k, v + "!"}
+	}, lists.Options{MaxConcurrency: 1})
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, StringSlice{"a!", "b!"}, legacy, "legacy map async context should map back to original index")
+}
+
+func TestSliceAsyncErr(t *testing.T) {
+	var test = Slice[string]{"1", "x", "3"}
+
+	ret, err := MapAsyncErrTo(context.Background(), test, func(ctx context.Context, k int, v string) (int, error) {
+		return strconv.Atoi(v)
+	}, lists.Options{ErrorMode: lists.JoinErrors})
+	assert.Equal(t, []int{1, 0, 3}, ret, "elements which failed should be left to zero value")
+
+	var elemErr *lists.ElementError
+	assert.True(t, errors.As(err, &elemErr), "err should be a *lists.ElementError")
+	assert.Equal(t, 1, elemErr.Key, "key of the error should be the index of the element")
+
+	mapped, err := test.MapAsyncErr(context.Background(), func(ctx context.Context, k int, v string) (string, error) {
+		return v + "!", nil
+	}, lists.Options{MaxConcurrency: 2})
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, Slice[string]{"1!", "x!", "3!"}, mapped, "map async err should map back to original index")
+
+	legacy, err := IntSlice{1, 2}.MapAsyncErr(context.Background(), func(ctx context.Context, k int, v int) (int, error) {
+		return v * 2, nil
+	}, lists.Options{})
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, IntSlice{2, 4}, legacy, "legacy map async err should map back to original index")
+}
+
+func TestSliceReduceAsyncContext(t *testing.T) {
+	var test = Slice[int]{1, 2, 3}
+
+	sum, err := test.ReduceAsyncContext(context.Background(), func(ctx context.Context, k int, v int, agg *lists.Aggregator[int]) {
+		state := <-agg.Agg
+		if v == 2 {
+			panic("boom")
+		}
+		agg.Done <- state + v
+	}, 0, lists.Options{OnPanic: lists.PanicContinue})
+
+	var elemErr *lists.ElementError
+	assert.True(t, errors.As(err, &elemErr), "err should be a *lists.ElementError")
+	assert.Equal(t, 1, elemErr.Key, "key should be the index of the element which panicked")
+	assert.Equal(t, 4, sum, "sum should skip the element which panicked")
+
+	legacy, err := StringSlice{"a", "b"}.ReduceAsyncContext(context.Background(), func(ctx context.Context, k int, v string, agg *lists.AsyncAggregator) {
+		agg.Done <- (<-agg.Agg).(string) + v
+	}, "", lists.Options{})
+	assert.Nil(t, err, "err should be nil")
+	assert.Equal(t, "ab", legacy, "legacy reduce async context should run in series")
+
+	_, err = test.MapAsyncErr(context.Background(), func(ctx context.Context, k int, v int) (int, error) {
+		panic("boom")
+	}, lists.Options{OnPanic: lists.PanicContinue})
+	var panicErr *lists.PanicError
+	assert.True(t, errors.As(err, &panicErr), "map async err should recover panics")
+}
+
+func TestSliceMapParallel(t *testing.T) {
+	var test = Slice[int]{1, 2, 3, 4}
+
+	for _, workers := range []int{0, 1, 3} {
+		ret := test.MapParallel(func(k int, v int) int {
+			if k == 0 {
+				time.Sleep(5 * time.Millisecond)
+			}
+			return v * v
+		}, workers)
+		assert.Equal(t, Slice[int]{1, 4, 9, 16}, ret, "map parallel should map back to original index")
+	}
+
+	strs := MapParallelTo(test, func(k int, v int) string {
+		return strconv.Itoa(v)
+	}, 2)
+	assert.Equal(t, []string{"1", "2", "3", "4"}, strs, "map parallel to should convert every element")
+
+	legacy := Float64Slice{1.5, 2.5}.MapParallel(func(k int, v float64) float64 {
+		return v * 2
+	}, 1)
+	assert.Equal(t, Float64Slice{3, 5}, legacy, "legacy map parallel should map back to original index")
+}
+
+func TestSliceAsyncDebug(t *testing.T) {
+	var protoErr *lists.ProtocolError
+	var elemErr *lists.ElementError
+
+	_, err := StringSlice{"a", "b"}.MapAsyncContext(context.Background(), func(_ context.Context, i int, v string, done chan [2]interface{}) {
+		done <- [2]interface{}{i, i}
+	}, lists.Options{})
+	assert.True(t, errors.As(err, &protoErr), "a value of the wrong type should be reported")
+	assert.Contains(t, err.Error(), "value written to the chan is a int, not a string")
+
+	lists.Debug = true
+	lists.DebugGrace = 50 * time.Millisecond
+	defer func() {
+		lists.Debug = false
+		lists.DebugGrace = 0
+	}()
+	_, err = Slice[int]{1, 2, 3}.MapAsyncContext(context.Background(), func(_ context.Context, i int, v int, done chan<- lists.Result[int, int]) {
+		if i != 1 {
+			done <- lists.Result[int, int]{Key: i, Value: v}
+		}
+	}, lists.Options{})
+	assert.True(t, errors.As(err, &elemErr), "err should be a *lists.ElementError")
+	assert.Equal(t, 1, elemErr.Key, "key should be the element which did not write to the chan")
+
+	assert.PanicsWithError(t, "lists: element 0: chan protocol: payload written to the chan more than once", func() {
+		IntSlice{1, 2}.MapAsync(func(i int, v int, done chan [2]int) {
+			done <- [2]int{0, v}
+		})
+	}, "MapAsync should panic with the error in debug mode")
+
+	func() {
+		defer func() {
+			err, _ := recover().(error)
+			assert.True(t, errors.As(err, &elemErr), "MapAsync should panic with a *lists.ElementError")
+			assert.True(t, errors.As(err, &protoErr), "the *lists.ElementError should wrap a *lists.ProtocolError")
+		}()
+		IntSlice{1, 2}.MapAsync(func(i int, v int, done chan [2]int) {
+			done <- [2]int{0, v}
+		})
+	}()
+}
+
+func TestSliceReduceParallel(t *testing.T) {
+	ints := make(IntSlice, 10000)
+	for i := range ints {
+		ints[i] = i
+	}
+	sum := func(a, b int) int {
+		return a + b
+	}
+	assert.Equal(t, 49995000, ints.ReduceParallel(0, sum, 4), "sum should be 49995000")
+	assert.Equal(t, ints.Reduce(func(_ int, v int, agg interface{}) interface{} {
+		return agg.(int) + v
+	}, 0), ints.ReduceParallel(0, sum, 0), "ReduceParallel should give the same result as Reduce")
+
+	floats := Float64Slice{1.5, 2.5, 3, 4}
+	assert.Equal(t, 4.0, floats.ReduceParallel(math.Inf(-1), math.Max, 2), "max should be 4")
+
+	words := Slice[string]{"hello", " ", "world"}
+	assert.Equal(t, "hello world", words.ReduceParallel("", func(a, b string) string {
+		return a + b
+	}, 3), "words should be concatenated in order")
+}
+
+func TestSliceAsyncStream(t *testing.T) {
+	test := Slice[int]{3, 2, 1}
+	cb := func(_ context.Context, k int, v int, done chan<- lists.Result[int, int]) {
+		time.Sleep(time.Duration(v) * 20 * time.Millisecond)
+		done <- lists.Result[int, int]{Key: k, Value: v * 2}
+	}
+
+	results, errc := test.MapAsyncStream(context.Background(), cb, lists.Options{})
+	var got []int
+	for r := range results {
+		got = append(got, r.Value)
+	}
+	assert.Nil(t, <-errc, "err should be nil")
+	assert.Equal(t, []int{2, 4, 6}, got, "results should be yielded as they complete")
+
+	results, errc = test.MapAsyncStream(context.Background(), cb, lists.Options{Ordered: true})
+	got = nil
+	for r := range results {
+		got = append(got, r.Value)
+	}
+	assert.Nil(t, <-errc, "err should be nil")
+	assert.Equal(t, []int{6, 4, 2}, got, "results should be yielded in order")
+
+	// a consumer which stops reading does not leak the stream once the context is cancelled
+	ctx, cancel := context.WithCancel(context.Background())
+	strs, errc := MapAsyncStreamTo(ctx, StringSlice{"a", "b", "c"}, func(ctx context.Context, k int, v string, done chan<- lists.Result[int, string]) {
+		if k > 0 {
+			// hangs until the context is done
+			<-ctx.Done()
+			return
+		}
+		done <- lists.Result[int, string]{Key: k, Value: v + "!"}
+	}, lists.Options{Ordered: true})
+	assert.Equal(t, "a!", (<-strs).Value, "first result should be a!")
+	cancel()
+	select {
+	case err := <-errc:
+		assert.Equal(t, context.Canceled, err, "err should be context.Canceled")
+	case <-time.After(time.Second):
+		t.Error("stream should end once the context is cancelled")
+	}
+
+	ints, errc := IntSlice{1, 2, 3}.MapAsyncStream(context.Background(), func(_ context.Context, k int, v int, done chan<- lists.Result[int, int]) {
+		time.Sleep(time.Duration(3-k) * 20 * time.Millisecond)
+		done <- lists.Result[int, int]{Key: k, Value: v * 2}
+	}, lists.Options{Ordered: true})
+	var gotInts []int
+	for r := range ints {
+		gotInts = append(gotInts, r.Value)
+	}
+	assert.Nil(t, <-errc, "err should be nil")
+	assert.Equal(t, []int{2, 4, 6}, gotInts, "legacy types should stream their results in order")
+}
+
+func TestSliceAsyncRateLimit(t *testing.T) {
+	test := make(StringSlice, 15)
+	start := time.Now()
+	// 5 go routines at once, then one every 10ms
+	result, err := test.MapAsyncInterfaceContext(context.Background(), func(_ context.Context, k int, v string, done chan [2]interface{}) {
+		done <- [2]interface{}{k, time.Since(start)}
+	}, lists.Options{RateLimit: lists.NewLimiter(100, 5)})
+	assert.Nil(t, err, "err should be nil")
+	assert.True(t, result[4].(time.Duration) < 50*time.Millisecond, "the burst should start at once")
+	assert.True(t, time.Since(start) >= 90*time.Millisecond, "the rate should be limited to 100 per second")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = Slice[int]{1, 2, 3}.MapAsyncContext(ctx, func(_ context.Context, k int, v int, done chan<- lists.Result[int, int]) {
+		done <- lists.Result[int, int]{Key: k, Value: v}
+	}, lists.Options{RateLimit: lists.NewLimiter(1, 1)})
+	assert.Equal(t, context.DeadlineExceeded, err, "waiting for a token should stop with the context")
+}
+
+func TestSliceAsyncRetry(t *testing.T) {
+	errTransient := errors.New("transient")
+	var mu sync.Mutex
+	attempts := map[int]int{}
+	outcomes, err := Slice[string]{"a", "b", "c"}.MapAsyncRetry(context.Background(), func(_ context.Context, k int, v string) (string, error) {
+		mu.Lock()
+		attempts[k]++
+		n := attempts[k]
+		mu.Unlock()
+		if n <= k {
+			return "", errTransient
+		}
+		return v + "!", nil
+	}, lists.Options{Retry: &lists.RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond, Jitter: 0.5}})
+	assert.Nil(t, err, "err should be nil")
+	for k, v := range []string{"a!", "b!", "c!"} {
+		assert.Equal(t, v, outcomes[k].Value, "value should be mapped")
+		assert.Equal(t, k+1, outcomes[k].Attempts, "element %d should take %d attempts", k, k+1)
+		assert.Nil(t, outcomes[k].Err, "element should succeed")
+	}
+
+	// MapAsyncErr retries too, and returns the error of the last attempt
+	var calls int32
+	_, err = IntSlice{1}.MapAsyncErr(context.Background(), func(_ context.Context, k int, v int) (int, error) {
+		atomic.AddInt32(&calls, 1)
+		return 0, errTransient
+	}, lists.Options{Retry: &lists.RetryPolicy{MaxAttempts: 2}})
+	assert.True(t, errors.Is(err, errTransient), "err should be the error of the last attempt")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls), "element should be called MaxAttempts times")
+}
+
+func TestSliceAsyncTimeout(t *testing.T) {
+	test := StringSlice{"fast", "slow", "fast"}
+	cb := func(ctx context.Context, k int, v string, done chan [2]interface{}) {
+		if v == "slow" {
+			select {
+			case <-ctx.Done():
+			case <-time.After(time.Second):
+			}
+		}
+		done <- [2]interface{}{k, v + "!"}
+	}
+
+	start := time.Now()
+	result, err := test.MapAsyncContext(context.Background(), cb, lists.Options{ElementTimeout: 20 * time.Millisecond, TimeoutFallback: "n/a"})
+	assert.Nil(t, err, "err should be nil with a fallback")
+	assert.Equal(t, StringSlice{"fast!", "n/a", "fast!"}, result, "slow element should be set to the fallback")
+	assert.True(t, time.Since(start) < 500*time.Millisecond, "slow element should not stall the call")
+
+	_, err = test.MapAsyncErr(context.Background(), func(ctx context.Context, k int, v string) (string, error) {
+		if v == "slow" {
+			time.Sleep(time.Second)
+		}
+		return v, nil
+	}, lists.Options{ErrorMode: lists.JoinErrors, ElementTimeout: 20 * time.Millisecond})
+	var elemErr *lists.ElementError
+	assert.True(t, errors.As(err, &elemErr), "err should be a *lists.ElementError")
+	assert.Equal(t, 1, elemErr.Key, "key should be the element which timed out")
+	assert.True(t, errors.Is(err, lists.ErrElementTimeout), "err should be lists.ErrElementTimeout")
+}
+
+func TestSliceAsyncProgress(t *testing.T) {
+	progress := lists.NewProgress()
+	test := make(IntSlice, 100)
+	_, err := test.MapAsyncErr(context.Background(), func(_ context.Context, k int, v int) (int, error) {
+		if k%10 == 0 {
+			return 0, errors.New("boom")
+		}
+		return k, nil
+	}, lists.Options{MaxConcurrency: 10, ErrorMode: lists.JoinErrors, Observer: progress})
+	assert.NotNil(t, err, "err should not be nil")
+
+	s := progress.Snapshot()
+	assert.Equal(t, 100, s.Completed, "every element should be completed")
+	assert.Equal(t, 10, s.Failed, "10 elements should have failed")
+	assert.Equal(t, 0, s.InFlight, "no element should be in flight")
+
+	// elements still running when the call is aborted are reported once they return
+	progress = lists.NewProgress()
+	release := make(chan struct{})
+	_, err = IntSlice{0, 1, 2, 3}.MapAsyncErr(context.Background(), func(_ context.Context, k int, v int) (int, error) {
+		if k == 0 {
+			return 0, errors.New("boom")
+		}
+		<-release
+		return k, nil
+	}, lists.Options{ErrorMode: lists.FirstError, Observer: progress})
+	assert.NotNil(t, err, "err should not be nil")
+
+	assert.Equal(t, 3, progress.Snapshot().InFlight, "elements still running should stay in flight")
+	close(release)
+	assert.Eventually(t, func() bool {
+		return progress.Snapshot().InFlight == 0
+	}, time.Second, time.Millisecond, "no element should be in flight once their go routines returned")
+	s = progress.Snapshot()
+	assert.Equal(t, 1, s.Completed, "aborted elements should not be completed")
+	assert.Equal(t, 1, s.Failed, "aborted elements should not be failed")
+	assert.Equal(t, 3, s.Aborted, "elements still running should be aborted")
+}
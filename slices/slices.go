@@ -1,33 +1,44 @@
 package slices
 
-import "reflect"
+import (
+	"context"
 
-func intfSlice(slice interface{}) []interface{} {
-	s := reflect.ValueOf(slice)
-	if s.Kind() != reflect.Slice && s.Kind() != reflect.Array {
-		panic("intfSlice() given a non-slice type")
-	}
-
-	ret := make([]interface{}, s.Len())
+	"github.com/francoispqt/lists"
+	"github.com/francoispqt/lists/internal/async"
+)
 
-	for i := 0; i < s.Len(); i++ {
-		ret[i] = s.Index(i).Interface()
-	}
+// mapAsyncIntf runs the async map protocol of the legacy types,
+// where go routines write a [2]interface{} to the chan, the first element being the index and the second one a U.
+func mapAsyncIntf[T any, U any](c []T, cb func(int, T, chan [2]interface{}), maxConcurrency []int, store func(int, U)) {
+	async.Map(async.Conc(maxConcurrency), intfJob(c, func(_ context.Context, i int, v T, mapChan chan [2]interface{}) {
+		cb(i, v, mapChan)
+	}, store))
+}
 
-	return ret
+// mapAsyncIntfContext is mapAsyncIntf with a context given to every go routine.
+func mapAsyncIntfContext[T any, U any](ctx context.Context, c []T, cb func(context.Context, int, T, chan [2]interface{}), opts lists.Options, store func(int, U)) error {
+	return async.MapContext(ctx, opts, intfJob(c, cb, store))
 }
 
-func compare(X, Y interface{}) bool {
-	xx := intfSlice(X)
-	yy := intfSlice(Y)
-	for k, v := range xx {
-		if len(yy)-1 >= k {
-			vv := yy[k]
-			if vv == v {
-				continue
-			}
-		}
-		return false
+// intfJob returns the job of the [2]interface{} protocol, giving the values to store.
+func intfJob[T any, U any](c []T, cb func(context.Context, int, T, chan [2]interface{}), store func(int, U)) async.Job[[2]interface{}] {
+	return async.Job[[2]interface{}]{
+		N:   len(c),
+		Key: async.Index,
+		Launch: func(ctx context.Context, i int, mapChan chan [2]interface{}) {
+			cb(ctx, i, c[i], mapChan)
+		},
+		Index: func(intf [2]interface{}) (int, error) {
+			i, _, err := async.Intf[int, U](intf)
+			return i, err
+		},
+		Store: func(i int, intf [2]interface{}) {
+			v, _ := async.As[U](intf[1])
+			store(i, v)
+		},
+		Fallback: func(i int, v interface{}) ([2]interface{}, bool) {
+			_, ok := async.As[U](v)
+			return [2]interface{}{i, v}, ok
+		},
 	}
-	return true
 }
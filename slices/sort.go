@@ -0,0 +1,255 @@
+package slices
+
+import (
+	"reflect"
+	"sort"
+)
+
+// Ordered is the constraint of the element types which can be sorted without a less func.
+type Ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64 | ~string
+}
+
+func isNaN[T Ordered](v T) bool {
+	return v != v
+}
+
+// less orders NaNs before any other value, as sort.Float64s does.
+func less[T Ordered](a, b T) bool {
+	return a < b || (isNaN(a) && !isNaN(b))
+}
+
+// Sort func creates a new slice with the elements of c sorted in ascending order, NaNs first.
+// Returns a slice of the type of c.
+func Sort[S ~[]T, T Ordered](c S) S {
+	var ret = append(make(S, 0, len(c)), c...)
+	sortOrdered(ret)
+	return ret
+}
+
+// SortDesc func creates a new slice with the elements of c sorted in descending order, NaNs last.
+// Returns a slice of the type of c.
+func SortDesc[S ~[]T, T Ordered](c S) S {
+	var ret = Sort(c)
+	for i, j := 0, len(ret)-1; i < j; i, j = i+1, j-1 {
+		ret[i], ret[j] = ret[j], ret[i]
+	}
+	return ret
+}
+
+// sortOrdered sorts c in place in the order of less by an introsort: a quicksort with a median of three pivot,
+// falling back to a heapsort when it goes too deep and to an insertion sort for short ranges.
+// It avoids the cost of the sort.Interface calls of sort.Slice.
+func sortOrdered[T Ordered](c []T) {
+	depth := 0
+	for n := len(c); n > 0; n >>= 1 {
+		depth += 2
+	}
+	introSort(c, depth)
+}
+
+func introSort[T Ordered](c []T, depth int) {
+	for len(c) > 12 {
+		if depth == 0 {
+			heapSort(c)
+			return
+		}
+		depth--
+		p := partition(c)
+		// recurse on the shorter side to bound the stack
+		if p < len(c)-p {
+			introSort(c[:p], depth)
+			c = c[p+1:]
+		} else {
+			introSort(c[p+1:], depth)
+			c = c[:p]
+		}
+	}
+	insertionSort(c)
+}
+
+// partition moves the median of the first, middle and last elements to its sorted position p and returns p,
+// elements before p are not greater than it and elements after p are not lower.
+func partition[T Ordered](c []T) int {
+	hi := len(c) - 1
+	mid := hi / 2
+	if less(c[mid], c[0]) {
+		c[mid], c[0] = c[0], c[mid]
+	}
+	if less(c[hi], c[0]) {
+		c[hi], c[0] = c[0], c[hi]
+	}
+	if less(c[hi], c[mid]) {
+		c[hi], c[mid] = c[mid], c[hi]
+	}
+	// c[0] <= c[mid] <= c[hi], the pivot is moved next to the end
+	c[mid], c[hi-1] = c[hi-1], c[mid]
+	pivot := c[hi-1]
+	i, j := 0, hi-1
+	for {
+		for i++; less(c[i], pivot); i++ {
+		}
+		for j--; less(pivot, c[j]); j-- {
+		}
+		if i >= j {
+			break
+		}
+		c[i], c[j] = c[j], c[i]
+	}
+	c[i], c[hi-1] = c[hi-1], c[i]
+	return i
+}
+
+func insertionSort[T Ordered](c []T) {
+	for i := 1; i < len(c); i++ {
+		for j := i; j > 0 && less(c[j], c[j-1]); j-- {
+			c[j], c[j-1] = c[j-1], c[j]
+		}
+	}
+}
+
+func heapSort[T Ordered](c []T) {
+	for i := len(c)/2 - 1; i >= 0; i-- {
+		siftDown(c, i, len(c))
+	}
+	for end := len(c) - 1; end > 0; end-- {
+		c[0], c[end] = c[end], c[0]
+		siftDown(c, 0, end)
+	}
+}
+
+func siftDown[T Ordered](c []T, root, end int) {
+	for {
+		child := 2*root + 1
+		if child >= end {
+			return
+		}
+		if child+1 < end && less(c[child], c[child+1]) {
+			child++
+		}
+		if !less(c[root], c[child]) {
+			return
+		}
+		c[root], c[child] = c[child], c[root]
+		root = child
+	}
+}
+
+// SortStable func creates a new slice with the elements of c sorted by the provided less func, equal elements keeping their order.
+// Returns a slice of the type of c.
+func SortStable[S ~[]T, T any](c S, less func(a, b T) bool) S {
+	var ret = append(make(S, 0, len(c)), c...)
+	sort.SliceStable(ret, func(i, j int) bool {
+		return less(ret[i], ret[j])
+	})
+	return ret
+}
+
+// SortBy func creates a new slice with the elements of c sorted in ascending order of the key returned by the provided func,
+// elements with equal keys keeping their order. The key func is called once per element.
+// Returns a slice of the type of c. It is a func and not a method as methods cannot have type parameters.
+func SortBy[S ~[]T, T any, K Ordered](c S, key func(T) K) S {
+	type keyed struct {
+		key K
+		v   T
+	}
+	var pairs = make([]keyed, len(c))
+	for i, v := range c {
+		pairs[i] = keyed{key(v), v}
+	}
+	sort.SliceStable(pairs, func(i, j int) bool {
+		return less(pairs[i].key, pairs[j].key)
+	})
+	var ret = make(S, len(c))
+	for i, p := range pairs {
+		ret[i] = p.v
+	}
+	return ret
+}
+
+// IsSorted func reports whether the elements of c are sorted in ascending order, NaNs first.
+func IsSorted[S ~[]T, T Ordered](c S) bool {
+	return isSortedFunc(c, less[T])
+}
+
+func isSortedFunc[T any](c []T, less func(a, b T) bool) bool {
+	for i := 1; i < len(c); i++ {
+		if less(c[i], c[i-1]) {
+			return false
+		}
+	}
+	return true
+}
+
+// ranks of the kinds of values in an InterfaceSlice
+const (
+	rankNil = iota
+	rankBool
+	rankNumber
+	rankString
+	rankOther
+)
+
+func rank(v reflect.Value) int {
+	if !v.IsValid() {
+		return rankNil
+	}
+	switch v.Kind() {
+	case reflect.Bool:
+		return rankBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return rankNumber
+	case reflect.String:
+		return rankString
+	}
+	return rankOther
+}
+
+// lessInterface orders nil, then bools, then numbers of any type, then strings, then any other value.
+// Numbers are compared by value, NaNs first. Other values are equal to each other.
+func lessInterface(a, b interface{}) bool {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	ra, rb := rank(va), rank(vb)
+	if ra != rb {
+		return ra < rb
+	}
+	switch ra {
+	case rankBool:
+		return !va.Bool() && vb.Bool()
+	case rankString:
+		return va.String() < vb.String()
+	case rankNumber:
+		return lessNumber(va, vb)
+	}
+	return false
+}
+
+func lessNumber(a, b reflect.Value) bool {
+	isInt := func(v reflect.Value) bool {
+		return v.Kind() >= reflect.Int && v.Kind() <= reflect.Int64
+	}
+	isUint := func(v reflect.Value) bool {
+		return v.Kind() >= reflect.Uint && v.Kind() <= reflect.Uintptr
+	}
+	switch {
+	case isInt(a) && isInt(b):
+		return a.Int() < b.Int()
+	case isUint(a) && isUint(b):
+		return a.Uint() < b.Uint()
+	}
+	return less(toFloat(a), toFloat(b))
+}
+
+func toFloat(v reflect.Value) float64 {
+	switch {
+	case v.Kind() >= reflect.Int && v.Kind() <= reflect.Int64:
+		return float64(v.Int())
+	case v.Kind() >= reflect.Uint && v.Kind() <= reflect.Uintptr:
+		return float64(v.Uint())
+	}
+	return v.Float()
+}
@@ -0,0 +1,79 @@
+package slices
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSort(t *testing.T) {
+	var ints = IntSlice{3, 1, 2}
+	sorted := ints.Sort()
+	assert.Equal(t, IntSlice{1, 2, 3}, sorted, "ints should be sorted")
+	assert.Equal(t, IntSlice{3, 1, 2}, ints, "the original slice should be left unchanged")
+	assert.Equal(t, IntSlice{3, 2, 1}, ints.SortDesc(), "ints should be sorted in descending order")
+	assert.True(t, sorted.IsSorted(), "sorted should be sorted")
+	assert.False(t, ints.IsSorted(), "ints should not be sorted")
+	assert.Equal(t, IntSlice{4, 6}, ints.Sort().Filter(func(k int, v int) bool {
+		return v > 1
+	}).Map(func(k int, v int) int {
+		return v * 2
+	}), "sort should chain with Filter and Map")
+
+	var strs = StringSlice{"foo", "bar", "baz", "qux"}
+	assert.Equal(t, StringSlice{"bar", "baz", "foo", "qux"}, strs.Sort(), "strings should be sorted")
+	assert.Equal(t, StringSlice{"qux", "foo", "baz", "bar"}, strs.SortDesc(), "strings should be sorted in descending order")
+	assert.Equal(t, StringSlice{"bar", "baz", "foo", "qux"}, strs.SortStable(func(a, b string) bool {
+		return a[0] < b[0]
+	}), "equal elements should keep their order")
+	byLen := SortBy(StringSlice{"ccc", "dd", "a", "bb"}, func(v string) int {
+		return len(v)
+	})
+	assert.Equal(t, StringSlice{"a", "dd", "bb", "ccc"}, byLen, "SortBy should sort by key and keep the order of equal keys")
+
+	nan := math.NaN()
+	floats := Float64Slice{2, nan, -1, math.Inf(1), 0}.Sort()
+	assert.True(t, math.IsNaN(floats[0]), "NaN should be first")
+	assert.Equal(t, Float64Slice{-1, 0, 2, math.Inf(1)}, floats[1:], "floats should be sorted")
+	assert.True(t, floats.IsSorted(), "floats should be sorted")
+	desc := Float64Slice{2, nan, -1}.SortDesc()
+	assert.True(t, math.IsNaN(desc[2]), "NaN should be last")
+	assert.Equal(t, Float64Slice{2, -1}, desc[:2], "floats should be sorted in descending order")
+	assert.False(t, Float64Slice{1, nan}.IsSorted(), "NaN after a number should not be sorted")
+
+	floats32 := Float32Slice{2, float32(nan), 1}.Sort()
+	assert.True(t, floats32[0] != floats32[0], "NaN should be first")
+	assert.Equal(t, Float32Slice{1, 2}, floats32[1:], "float32 should be sorted")
+	assert.Equal(t, Float32Slice{2, 1}, Float32Slice{1, 2}.SortDesc(), "float32 should be sorted in descending order")
+	assert.True(t, Float32Slice{1, 2}.IsSorted(), "float32 should be sorted")
+	assert.Equal(t, Float64Slice{2, 1}, Float64Slice{1, 2}.SortStable(func(a, b float64) bool {
+		return a > b
+	}), "float64 should be sorted by less")
+	assert.Equal(t, Float32Slice{2, 1}, Float32Slice{1, 2}.SortStable(func(a, b float32) bool {
+		return a > b
+	}), "float32 should be sorted by less")
+	assert.Equal(t, IntSlice{2, 1}, IntSlice{1, 2}.SortStable(func(a, b int) bool {
+		return a > b
+	}), "ints should be sorted by less")
+	assert.True(t, StringSlice{"a", "b"}.IsSorted(), "strings should be sorted")
+
+	var intfs = InterfaceSlice{"b", 2.5, nil, struct{}{}, true, uint8(1), "a", false, 3}
+	assert.Equal(t, InterfaceSlice{nil, false, true, uint8(1), 2.5, 3, "a", "b", struct{}{}}, intfs.Sort(), "interfaces should be sorted by kind and value")
+	assert.Equal(t, InterfaceSlice{struct{}{}, "b", "a", 3, 2.5, uint8(1), true, false, nil}, intfs.SortDesc(), "interfaces should be sorted in descending order")
+	assert.True(t, intfs.Sort().IsSorted(), "sorted interfaces should be sorted")
+	assert.False(t, intfs.IsSorted(), "interfaces should not be sorted")
+	assert.Equal(t, InterfaceSlice{int64(-1), uint(1)}, InterfaceSlice{uint(1), int64(-1)}.Sort(), "mixed ints should be compared by value")
+	assert.Equal(t, InterfaceSlice{2, 1}, InterfaceSlice{1, 2}.SortStable(func(a, b interface{}) bool {
+		return a.(int) > b.(int)
+	}), "interfaces should be sorted by less")
+
+	assert.Equal(t, Slice[int]{2, 1}, Slice[int]{1, 2}.SortStable(func(a, b int) bool {
+		return a > b
+	}), "generic slice should be sorted by less")
+	assert.Equal(t, AnySlice[[]int]{{1}, {1, 2}}, AnySlice[[]int]{{1, 2}, {1}}.SortStable(func(a, b []int) bool {
+		return len(a) < len(b)
+	}), "any slice should be sorted by less")
+	assert.Equal(t, Slice[int]{1, 2}, Sort(Slice[int]{2, 1}), "Sort func should sort a generic slice")
+	assert.Empty(t, IntSlice{}.Sort(), "empty slice should stay empty")
+}
@@ -1,243 +1,136 @@
 package slices
 
-import "github.com/francoispqt/lists"
+import (
+	"context"
 
-// StringSlice is a custom type for a slice of strings
+	"github.com/francoispqt/lists"
+)
+
+// StringSlice is a custom type for a slice of string.
+// It is kept for compatibility, its methods delegate to Slice[string].
 type StringSlice []string
 
 // Contains method determines whether a slice includes a certain element, returning true or false as appropriate.
+// It is a linear scan, for a sorted slice see BinarySearch.
 func (c StringSlice) Contains(s string) bool {
-	for _, v := range c {
-		if v == s {
-			return true
-		}
-	}
-	return false
+	return Slice[string](c).Contains(s)
 }
 
 // ForEach method executes a provided func once for each slice element.
 func (c StringSlice) ForEach(cb func(int, string)) {
-	for k, v := range c {
-		cb(k, v)
-	}
+	Slice[string](c).ForEach(cb)
 }
 
 // Map method creates a new slice with the results of calling a provided func on every element in the calling array.
-// Returns a slice of string (original type).
+// Returns a StringSlice (original type).
 // For asynchronicity, see MapAsync.
 func (c StringSlice) Map(cb func(int, string) string) StringSlice {
-	var ret = make([]string, len(c))
-	for k, v := range c {
-		ret[k] = cb(k, v)
-	}
-	return ret
+	return StringSlice(Slice[string](c).Map(cb))
 }
 
 // MapInterface method creates a new slice with the results of calling a provided func on every element in the calling array.
 // Returns a slice of interfaces.
 // For asynchronicity, see MapAsyncInterface.
 func (c StringSlice) MapInterface(cb func(int, string) interface{}) InterfaceSlice {
-	var ret = make([]interface{}, len(c))
-	for k, v := range c {
-		ret[k] = cb(k, v)
-	}
-	return ret
+	return Slice[string](c).MapInterface(cb)
 }
 
 // MapAsync method creates a new slice with the results of calling a provided go routine on every element in the calling array.
-// Runs asynchronously and needs gives a chan []interface{} to return results.
-// To keep initial order, the first elemt of th []interface{} written to the chan must be the key. The second element muse be a string.
+// Runs asynchronously and gives a chan [2]interface{} to return results.
+// To keep initial order, the first element of the [2]interface{} written to the chan must be the index. The second element must be a string.
 // Returns a StringSlice (original type).
-// If you want to map to a slice of different type, see MapAsyncInterface.
+// For a typed chan, see Slice.MapAsync.
 func (c StringSlice) MapAsync(cb func(int, string, chan [2]interface{}), maxConcurrency ...int) StringSlice {
+	var ret = make(StringSlice, len(c))
+	mapAsyncIntf(c, cb, maxConcurrency, func(i int, v string) {
+		ret[i] = v
+	})
+	return ret
+}
 
-	var maxConc = lists.DEFAULT_CONC
-	if len(maxConcurrency) == 1 {
-		maxConc = maxConcurrency[0]
-	}
-
-	var mapChan = make(chan [2]interface{}, len(c))
-	var doing chan struct{}
-	var ret = make([]string, len(c))
-	var i = 0
-	var received = 0
-
-	if maxConc > 0 {
-		doing = make(chan struct{}, maxConc)
-	} else {
-		doing = make(chan struct{})
-	}
+// MapAsyncContext method is MapAsync with a context given to every go routine.
+// When the context is done, no more go routine is started and MapAsyncContext returns ctx.Err() with the results received so far.
+func (c StringSlice) MapAsyncContext(ctx context.Context, cb func(context.Context, int, string, chan [2]interface{}), opts lists.Options) (StringSlice, error) {
+	var ret = make(StringSlice, len(c))
+	err := mapAsyncIntfContext(ctx, c, cb, opts, func(i int, v string) {
+		ret[i] = v
+	})
+	return ret, err
+}
 
-	for {
-
-		// if maxConc == 0 go ahead or if maxConc is higher than 0 length of chan doing is lower than maxConc && counter is lower than lenght of slice continue
-		// else start reading from the chan to decrease concurrency
-		if maxConc == 0 || (len(doing) < maxConc && i < len(c)) {
-			v := c[i]
-			go cb(i, v, mapChan)
-			i++
-			if maxConc > 0 {
-				doing <- struct{}{}
-			}
-			if maxConc == 0 && i == len(c) {
-				break
-			}
-		} else {
-
-			// start reading my chan
-			intf := <-mapChan
-			received++
-
-			if len(intf) > 1 {
-				ret[intf[0].(int)] = intf[1].(string)
-			} else {
-				ret[intf[0].(int)] = ""
-			}
-
-			// reading doing to continue the loop
-			<-doing
-
-			if received == len(c) {
-				return ret
-			}
-			continue
-		}
-	}
+// MapAsyncErr method creates a new slice with the values returned by calling a provided func as a go routine on every element in the calling array.
+// Errors returned by the func are collected as set by opts.ErrorMode and wrapped in a *lists.ElementError, elements which failed are left to their zero value.
+// The context is given to every go routine, when it is done no more go routine is started.
+func (c StringSlice) MapAsyncErr(ctx context.Context, cb func(context.Context, int, string) (string, error), opts lists.Options) (StringSlice, error) {
+	return MapAsyncErrTo(ctx, c, cb, opts)
+}
 
-	// is max concurenccy is 0, means no limit
-	// so we only start reading the result chan here
-	if maxConc == 0 {
-		ct := 0
-		for intf := range mapChan {
-			if len(intf) > 1 {
-				ret[intf[0].(int)] = intf[1].(string)
-			} else {
-				ret[intf[0].(int)] = ""
-			}
-			ct++
-			if ct >= len(c) {
-				break
-			}
-		}
-	}
+// MapAsyncStream method calls a provided go routine on every element in the calling array and returns a chan yielding the results as soon as they are written.
+// With opts.Ordered, results are yielded in the order of the elements.
+// The chan is closed once every go routine is done, the error of the call (nil on success) is then written to the error chan.
+func (c StringSlice) MapAsyncStream(ctx context.Context, cb func(context.Context, int, string, chan<- lists.Result[int, string]), opts lists.Options) (<-chan lists.Result[int, string], <-chan error) {
+	return MapAsyncStreamTo(ctx, c, cb, opts)
+}
 
-	return ret
+// MapParallel method creates a new slice with the values returned by calling a provided func as a go routine on every element in the calling array.
+// No more than workers go routines run at the same time, 0 means no limit.
+// Returns a StringSlice (original type).
+func (c StringSlice) MapParallel(cb func(int, string) string, workers int) StringSlice {
+	return MapParallelTo(c, cb, workers)
 }
 
 // MapAsyncInterface method creates a new slice with the results of calling a provided go routine on every element in the calling array.
-// Runs asynchronously and needs gives a chan []interface{} to return results.
-// To keep initial order, the first elemt of th []interface{} written to the chan must be the key. The second element muse be a string.
+// Runs asynchronously and gives a chan [2]interface{} to return results.
+// To keep initial order, the first element of the [2]interface{} written to the chan must be the index.
 // Returns InterfaceSlice.
-// If you know the result will be of original type, user MapAsync.
-// @Todo implement max concurrency (in case a lot of requests for example)
+// If you know the result will be of original type, use MapAsync.
 func (c StringSlice) MapAsyncInterface(cb func(int, string, chan [2]interface{}), maxConcurrency ...int) InterfaceSlice {
-	var maxConc = lists.DEFAULT_CONC
-	if len(maxConcurrency) == 1 {
-		maxConc = maxConcurrency[0]
-	}
-
-	var mapChan = make(chan [2]interface{}, len(c))
-	var doing chan struct{}
-	var ret = make([]interface{}, len(c))
-	var i = 0
-	var received = 0
-
-	if maxConc > 0 {
-		doing = make(chan struct{}, maxConc)
-	} else {
-		doing = make(chan struct{})
-	}
-
-	for {
-
-		// if maxConc == 0 go ahead or if maxConc is higher than 0 length of chan doing is lower than maxConc && counter is lower than lenght of slice continue
-		// else start reading from the chan to decrease concurrency
-		if maxConc == 0 || (len(doing) < maxConc && i < len(c)) {
-			v := c[i]
-			go cb(i, v, mapChan)
-			i++
-			if maxConc > 0 {
-				doing <- struct{}{}
-			}
-			if maxConc == 0 && i == len(c) {
-				break
-			}
-		} else {
-
-			// start reading my chan
-			intf := <-mapChan
-			received++
-
-			if len(intf) > 1 {
-				ret[intf[0].(int)] = intf[1]
-			} else {
-				ret[intf[0].(int)] = nil
-			}
-
-			// reading doing to continue the loop
-			<-doing
-
-			if received == len(c)-1 {
-				return ret
-			}
-		}
-	}
-
-	// is max concurenccy is 0, means no limit
-	// so we only start reading the result chan here
-	if maxConc == 0 {
-		ct := 0
-		for intf := range mapChan {
-			if len(intf) > 1 {
-				ret[intf[0].(int)] = intf[1]
-			} else {
-				ret[intf[0].(int)] = nil
-			}
-			ct++
-			if ct >= len(c) {
-				break
-			}
-		}
-	}
-
+	var ret = make(InterfaceSlice, len(c))
+	mapAsyncIntf(c, cb, maxConcurrency, func(i int, v interface{}) {
+		ret[i] = v
+	})
 	return ret
 }
 
+// MapAsyncInterfaceContext method is MapAsyncInterface with a context given to every go routine.
+// When the context is done, no more go routine is started and MapAsyncInterfaceContext returns ctx.Err() with the results received so far.
+func (c StringSlice) MapAsyncInterfaceContext(ctx context.Context, cb func(context.Context, int, string, chan [2]interface{}), opts lists.Options) (InterfaceSlice, error) {
+	var ret = make(InterfaceSlice, len(c))
+	err := mapAsyncIntfContext(ctx, c, cb, opts, func(i int, v interface{}) {
+		ret[i] = v
+	})
+	return ret, err
+}
+
 // Reduce method applies a func against an accumulator and each element in the slice (from left to right) to reduce it to a single value of any type.
 // If no accumulator is passed as second argument, default accumulator will be nil
 // Returns an interface.
 // For asynchronicity, see ReduceAsync.
 func (c StringSlice) Reduce(cb func(int, string, interface{}) interface{}, defAgg ...interface{}) interface{} {
 	var agg interface{}
-	if len(defAgg) == 0 {
-		agg = nil
-	} else {
+	if len(defAgg) > 0 {
 		agg = defAgg[0]
 	}
-	for k, v := range c {
-		agg = cb(k, v, agg)
-	}
-	return agg
+	return Reduce(c, cb, agg)
 }
 
-// Reduce method applies a go routinge against an accumulator and each element in the slice (from left to right) to reduce it to a single value of any type.
+// ReduceAsync method applies a go routine against an accumulator and each element in the slice (from left to right) to reduce it to a single value of any type.
+// You must read the aggregator from the lists.AsyncAggregator.Agg channel before writing to the lists.AsyncAggregator.Done channel.
 // Returns an interface.
 // For synchronicity, see Reduce.
 func (c StringSlice) ReduceAsync(cb func(int, string, *lists.AsyncAggregator), defAgg ...interface{}) interface{} {
-	agg := &lists.AsyncAggregator{
-		Done: make(chan interface{}, len(c)),
-		Agg:  make(chan interface{}, len(c)),
-	}
-	if len(defAgg) == 0 {
-		agg.Agg <- nil
-	} else {
-		agg.Agg <- defAgg[0]
-	}
-	for k, v := range c {
-		go cb(k, v, agg)
-		agg.Agg <- <-agg.Done
+	var agg interface{}
+	if len(defAgg) > 0 {
+		agg = defAgg[0]
 	}
-	return <-agg.Agg
+	return ReduceAsync(c, cb, agg)
+}
+
+// ReduceAsyncContext method is ReduceAsync with a context given to every go routine.
+// When the context is done, no more go routine is started and the current state of the accumulator is returned with ctx.Err().
+// Panics are recovered as set by opts.OnPanic, the state of the accumulator is left unchanged by a go routine which panicked.
+func (c StringSlice) ReduceAsyncContext(ctx context.Context, cb func(context.Context, int, string, *lists.AsyncAggregator), agg interface{}, opts lists.Options) (interface{}, error) {
+	return ReduceAsyncContext(ctx, c, cb, agg, opts)
 }
 
 // IsLast checks if the index passed is the last of the slice
@@ -247,27 +140,100 @@ func (c StringSlice) IsLast(i int) bool {
 
 // Indexes returns a slice of ints with including the indexes of the StringSlice
 func (c StringSlice) Indexes() []int {
-	var indexes = []int{}
-	for i := 0; i < len(c); i++ {
-		indexes = append(indexes, i)
-	}
-	return indexes
+	return Slice[string](c).Indexes()
 }
 
 // Filter method creates a new slice with all elements that pass the test implemented by the provided function.
 func (c StringSlice) Filter(cb func(k int, v string) bool) StringSlice {
-	var ret = make([]string, 0)
-	for k, v := range c {
-		if cb(k, v) {
-			ret = append(ret, v)
-		}
-	}
-	return ret
+	return StringSlice(Slice[string](c).Filter(cb))
+}
+
+// Sort method creates a new slice with the elements sorted in ascending order.
+// Returns a StringSlice (original type).
+func (c StringSlice) Sort() StringSlice {
+	return Sort(c)
+}
+
+// SortDesc method creates a new slice with the elements sorted in descending order.
+// Returns a StringSlice (original type).
+func (c StringSlice) SortDesc() StringSlice {
+	return SortDesc(c)
+}
+
+// SortStable method creates a new slice with the elements sorted by the provided less func, equal elements keeping their order.
+// Returns a StringSlice (original type). To sort by a key, see SortBy.
+func (c StringSlice) SortStable(less func(a, b string) bool) StringSlice {
+	return SortStable(c, less)
+}
+
+// IsSorted method reports whether the elements are sorted in ascending order.
+func (c StringSlice) IsSorted() bool {
+	return IsSorted(c)
+}
+
+// BinarySearch method returns the index of the first element equal to v and true,
+// or the index at which v would be inserted and false if the slice does not contain v.
+// The slice must be sorted in the order of Sort.
+func (c StringSlice) BinarySearch(v string) (int, bool) {
+	return binarySearchFunc(c, v, less[string])
+}
+
+// LowerBound method returns the index of the first element not lower than v, len(c) if there is none.
+// The slice must be sorted in the order of Sort.
+func (c StringSlice) LowerBound(v string) int {
+	return lowerBoundFunc(c, v, less[string])
+}
+
+// UpperBound method returns the index of the first element greater than v, len(c) if there is none.
+// The slice must be sorted in the order of Sort.
+func (c StringSlice) UpperBound(v string) int {
+	return upperBoundFunc(c, v, less[string])
+}
+
+// InsertSorted method creates a new slice with v inserted after the elements equal to it, so that it stays sorted.
+// The slice must be sorted in the order of Sort. Returns a StringSlice (original type).
+func (c StringSlice) InsertSorted(v string) StringSlice {
+	return insertSortedFunc(c, v, less[string])
+}
+
+// RangeBetween method returns the elements between lo and hi included, as a sub-slice sharing its elements with c.
+// The slice must be sorted in the order of Sort. Returns a StringSlice (original type).
+func (c StringSlice) RangeBetween(lo, hi string) StringSlice {
+	return rangeBetweenFunc(c, lo, hi, less[string])
+}
+
+// Union method creates a new slice with the elements of c then the ones of s, without duplicates, in their order.
+// Returns a StringSlice (original type).
+func (c StringSlice) Union(s StringSlice) StringSlice {
+	return Union(c, s)
+}
+
+// Intersect method creates a new slice with the elements of c which are in s, without duplicates, in their order.
+// Returns a StringSlice (original type).
+func (c StringSlice) Intersect(s StringSlice) StringSlice {
+	return Intersect(c, s)
+}
+
+// Difference method creates a new slice with the elements of c which are not in s, without duplicates, in their order.
+// Returns a StringSlice (original type).
+func (c StringSlice) Difference(s StringSlice) StringSlice {
+	return Difference(c, s)
+}
+
+// SymmetricDifference method creates a new slice with the elements of c which are not in s then the ones of s which are not in c,
+// without duplicates, in their order.
+// Returns a StringSlice (original type).
+func (c StringSlice) SymmetricDifference(s StringSlice) StringSlice {
+	return SymmetricDifference(c, s)
+}
+
+// ToChan method returns a chan yielding the elements of the slice in order, with a buffer of the given size.
+// The chan is closed once every element is written or ctx is done.
+func (c StringSlice) ToChan(ctx context.Context, buffer int) <-chan string {
+	return AnySlice[string](c).ToChan(ctx, buffer)
 }
 
 // Cast explicitly cast the StringSlice to a []string type
 func (c StringSlice) Cast() []string {
-	var dest []string
-	dest = c
-	return dest
+	return c
 }